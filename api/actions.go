@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	pathShopper      = "%s/v1/shoppers/%s?includes=customerId"
+	pathDomainAction = "%s/v2/customers/%s/domains/%s/actions/%s"
+
+	// ActionTransfer is the action tracking a transfer into the account
+	ActionTransfer = "DOMAIN_TRANSFER"
+
+	ActionAccepted  = "ACCEPTED"
+	ActionAwaiting  = "AWAITING"
+	ActionPending   = "PENDING"
+	ActionSuccess   = "SUCCESS"
+	ActionFailed    = "FAILED"
+	ActionCancelled = "CANCELLED"
+)
+
+// Action is an asynchronous change to a domain tracked by the v2 API
+type Action struct {
+	Type        string        `json:"type"`
+	Status      string        `json:"status"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	ModifiedAt  time.Time     `json:"modifiedAt"`
+	CompletedAt time.Time     `json:"completedAt"`
+	Reason      *ActionReason `json:"reason,omitempty"`
+}
+
+// ActionReason explains why an action failed
+type ActionReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IsDone reports whether the action completed, successfully or not
+func (a *Action) IsDone() bool {
+	switch a.Status {
+	case ActionSuccess, ActionFailed, ActionCancelled:
+		return true
+	}
+	return false
+}
+
+// ActionError is returned for an action that failed or was cancelled, e.g. a
+// transfer the losing registrar rejected
+type ActionError struct {
+	Domain string
+	Action *Action
+}
+
+func (e *ActionError) Error() string {
+	msg := fmt.Sprintf("%s of %s ended with status %s", e.Action.Type, e.Domain, e.Action.Status)
+	if e.Action.Reason != nil {
+		msg += fmt.Sprintf(": %s (%s)", e.Action.Reason.Message, e.Action.Reason.Code)
+	}
+	return msg
+}
+
+// GetCustomerID resolves the ID the v2 API knows the provided customer by,
+// defaulting to the customer of the client
+func (c *Client) GetCustomerID(ctx context.Context, shopperID string) (string, error) {
+	ctx = c.logContext(ctx, "")
+
+	if strings.TrimSpace(shopperID) == "" {
+		shopperID = c.customerID
+	}
+	if strings.TrimSpace(shopperID) == "" {
+		return "", errors.New("a customer is required to use the v2 API, configure the customer of the provider or of the resource")
+	}
+
+	if customerID, ok := c.cache.customer(shopperID); ok {
+		return customerID, nil
+	}
+
+	shopperURL := fmt.Sprintf(pathShopper, c.baseURL, shopperID)
+	req, err := http.NewRequest(http.MethodGet, shopperURL, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	shopper := &struct {
+		CustomerID string `json:"customerId"`
+	}{}
+	if err := c.execute(ctx, shopperID, req, shopper); err != nil {
+		return "", err
+	}
+
+	if shopper.CustomerID == "" {
+		return "", fmt.Errorf("no customer ID found for customer %s", shopperID)
+	}
+
+	c.cache.setCustomer(shopperID, shopper.CustomerID)
+	return shopper.CustomerID, nil
+}
+
+// GetDomainAction fetches the latest action of the type on the domain
+func (c *Client) GetDomainAction(ctx context.Context, customerID, domain, actionType string) (*Action, error) {
+	ctx = c.logContext(ctx, domain)
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.fetchDomainAction(ctx, customerID, v2CustomerID, domain, actionType)
+}
+
+func (c *Client) fetchDomainAction(ctx context.Context, customerID, v2CustomerID, domain, actionType string) (*Action, error) {
+	actionURL := fmt.Sprintf(pathDomainAction, c.baseURL, v2CustomerID, domain, actionType)
+	req, err := http.NewRequest(http.MethodGet, actionURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	action := new(Action)
+	if err := c.execute(ctx, customerID, req, action); err != nil {
+		return nil, err
+	}
+
+	return action, nil
+}
+
+// LastActionTime is the time GoDaddy created the latest action of the type on
+// the domain, zero if there is none. Read before a change is requested, it
+// tells the action of the change apart from earlier ones in WaitForAction.
+func (c *Client) LastActionTime(ctx context.Context, customerID, domain, actionType string) (time.Time, error) {
+	action, err := c.GetDomainAction(ctx, customerID, domain, actionType)
+	switch {
+	case IsNotFound(err):
+		return time.Time{}, nil
+	case err != nil:
+		return time.Time{}, err
+	}
+	return action.CreatedAt, nil
+}
+
+// WaitForAction polls the latest action of the type on the domain until it
+// completes or the timeout expires. Only an action created after the provided
+// time of the last action, see LastActionTime, counts: an action created
+// until then is left over from a previous change and the change is still
+// pending. An action that failed or was cancelled is returned along with an
+// *ActionError.
+func (c *Client) WaitForAction(ctx context.Context, customerID, domain, actionType string, after time.Time, timeout time.Duration) (*Action, error) {
+	ctx = c.logContext(ctx, domain)
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var action *Action
+	err = poll(ctx, domain, timeout, func() (string, error) {
+		var err error
+		action, err = c.fetchDomainAction(ctx, customerID, v2CustomerID, domain, actionType)
+
+		switch {
+		// the action may not be recorded right after the change is requested
+		case IsNotFound(err), err == nil && !after.IsZero() && !action.CreatedAt.After(after):
+			action = nil
+			return ActionAccepted, nil
+		case err != nil:
+			return "", err
+		case !action.IsDone():
+			return action.Status, nil
+		default:
+			return "", nil
+		}
+	})
+	if err != nil {
+		return action, err
+	}
+
+	c.cache.invalidate(c.cacheKey(customerID, domain))
+	if action.Status != ActionSuccess {
+		return action, &ActionError{Domain: domain, Action: action}
+	}
+	return action, nil
+}
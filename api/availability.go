@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	pathDomainAvailable  = "%s/v1/domains/available?domain=%s&checkType=%s"
+	pathDomainsAvailable = "%s/v1/domains/available?checkType=%s"
+
+	// maxAvailabilityDomains is the number of domains checked by a single bulk request
+	maxAvailabilityDomains = 500
+	// priceMicroUnits is the number of micro-units in a unit of currency
+	priceMicroUnits = 1000000
+)
+
+const (
+	// CheckTypeFast checks availability quickly, possibly from a cache
+	CheckTypeFast = "FAST"
+	// CheckTypeFull checks availability definitively, which is slower
+	CheckTypeFull = "FULL"
+)
+
+// DomainAvailability is the availability of a domain for registration. The
+// price is expressed in micro-units of the currency for the period in years.
+type DomainAvailability struct {
+	Domain     string `json:"domain"`
+	Available  bool   `json:"available"`
+	Definitive bool   `json:"definitive"`
+	Price      int64  `json:"price"`
+	Currency   string `json:"currency"`
+	Period     int    `json:"period"`
+}
+
+// PriceAmount is the price in units of the currency
+func (a *DomainAvailability) PriceAmount() float64 {
+	return float64(a.Price) / priceMicroUnits
+}
+
+// AvailabilityFailure is the failure to check a single domain of a bulk request
+type AvailabilityFailure struct {
+	Domain  string `json:"domain"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (f *AvailabilityFailure) String() string {
+	return fmt.Sprintf("%s [%s]: %s", f.Domain, f.Code, f.Message)
+}
+
+// AvailabilityError lists the domains of a bulk request that could not be
+// checked, while the others were
+type AvailabilityError struct {
+	Failures []AvailabilityFailure
+}
+
+func (e *AvailabilityError) Error() string {
+	failed := make([]string, len(e.Failures))
+	for i := range e.Failures {
+		failed[i] = e.Failures[i].String()
+	}
+	return fmt.Sprintf("couldn't check availability of %s", strings.Join(failed, ", "))
+}
+
+// CheckDomainAvailability checks whether the domain can be registered. A FAST
+// check may be served from a cache, a FULL one is definitive but slower.
+func (c *Client) CheckDomainAvailability(ctx context.Context, domain, checkType string) (*DomainAvailability, error) {
+	ctx = c.logContext(ctx, domain)
+
+	if checkType == "" {
+		checkType = CheckTypeFast
+	}
+
+	domainURL := fmt.Sprintf(pathDomainAvailable, c.baseURL, url.QueryEscape(domain), checkType)
+	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	availability := new(DomainAvailability)
+	if err := c.execute(ctx, "", req, availability); err != nil {
+		return nil, err
+	}
+
+	return availability, nil
+}
+
+// CheckDomainsAvailability checks whether the domains can be registered,
+// sending up to 500 domains per request. Domains that could not be checked
+// are reported in an *AvailabilityError along with the results of the other
+// domains.
+func (c *Client) CheckDomainsAvailability(ctx context.Context, domains []string, checkType string) ([]*DomainAvailability, error) {
+	ctx = c.logContext(ctx, "")
+
+	if checkType == "" {
+		checkType = CheckTypeFast
+	}
+
+	results := make([]*DomainAvailability, 0, len(domains))
+	var failures []AvailabilityFailure
+	for start := 0; start < len(domains); start += maxAvailabilityDomains {
+		end := start + maxAvailabilityDomains
+		if end > len(domains) {
+			end = len(domains)
+		}
+
+		msg, err := json.Marshal(domains[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		domainURL := fmt.Sprintf(pathDomainsAvailable, c.baseURL, checkType)
+		req, err := http.NewRequest(http.MethodPost, domainURL, bytes.NewBuffer(msg))
+
+		if err != nil {
+			return nil, err
+		}
+
+		page := struct {
+			Domains []*DomainAvailability `json:"domains"`
+			Errors  []AvailabilityFailure `json:"errors"`
+		}{}
+		if err := c.execute(ctx, "", req, &page); err != nil {
+			return nil, err
+		}
+
+		results = append(results, page.Domains...)
+		failures = append(failures, page.Errors...)
+	}
+
+	if len(failures) > 0 {
+		return results, &AvailabilityError{Failures: failures}
+	}
+
+	return results, nil
+}
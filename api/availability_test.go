@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDomainAvailability(t *testing.T) {
+	var query string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+		w.Header().Set(headerContent, mediaTypeJSON)
+		fmt.Fprint(w, `{"available":true,"currency":"USD","definitive":true,"domain":"example.com","period":1,"price":11990000}`)
+	}))
+
+	availability, err := client.CheckDomainAvailability(context.Background(), "example.com", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "domain=example.com&checkType=FAST", query)
+	assert.True(t, availability.Available)
+	assert.Equal(t, "USD", availability.Currency)
+	assert.Equal(t, 1, availability.Period)
+	assert.Equal(t, 11.99, availability.PriceAmount())
+}
+
+func TestCheckDomainsAvailability(t *testing.T) {
+	var batches []int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var domains []string
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &domains)
+		batches = append(batches, len(domains))
+
+		page := map[string]interface{}{}
+		results := make([]*DomainAvailability, 0)
+		for _, domain := range domains {
+			if strings.HasSuffix(domain, ".invalid") {
+				page["errors"] = []map[string]string{{"domain": domain, "code": "UNSUPPORTED_TLD", "message": "TLD is not supported"}}
+				continue
+			}
+			results = append(results, &DomainAvailability{Domain: domain, Available: true})
+		}
+		page["domains"] = results
+
+		w.Header().Set(headerContent, mediaTypeJSON)
+		json.NewEncoder(w).Encode(page)
+	}))
+
+	t.Run("Given more domains than a single request checks", func(t *testing.T) {
+		batches = nil
+		domains := make([]string, 1200)
+		for i := range domains {
+			domains[i] = fmt.Sprintf("example-%d.com", i)
+		}
+
+		results, err := client.CheckDomainsAvailability(context.Background(), domains, CheckTypeFull)
+		assert.Nil(t, err)
+		assert.Len(t, results, 1200)
+		assert.Equal(t, []int{500, 500, 200}, batches)
+	})
+
+	t.Run("Given a domain that can't be checked", func(t *testing.T) {
+		results, err := client.CheckDomainsAvailability(context.Background(), []string{"example.com", "example.invalid"}, "")
+		assert.Len(t, results, 1)
+		assert.EqualError(t, err, "couldn't check availability of example.invalid [UNSUPPORTED_TLD]: TLD is not supported")
+
+		var availabilityErr *AvailabilityError
+		if assert.True(t, errors.As(err, &availabilityErr)) {
+			assert.Equal(t, []AvailabilityFailure{{Domain: "example.invalid", Code: "UNSUPPORTED_TLD", Message: "TLD is not supported"}}, availabilityErr.Failures)
+		}
+	})
+}
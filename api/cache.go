@@ -0,0 +1,145 @@
+package api
+
+import (
+	"strings"
+	"sync"
+)
+
+// responseCache keeps the domain and record reads of a client until the
+// domain is written to. A nil cache never holds anything.
+type responseCache struct {
+	sync.Mutex
+	domains map[string]Domain
+	records map[string][]DomainRecord
+	// customers maps shoppers to the customer IDs of the v2 API, which
+	// never change
+	customers map[string]string
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		domains:   make(map[string]Domain),
+		records:   make(map[string][]DomainRecord),
+		customers: make(map[string]string),
+	}
+}
+
+// cacheKey identifies a domain as seen by the provided customer
+func (c *Client) cacheKey(customerID, domain string) string {
+	if strings.TrimSpace(customerID) == "" {
+		customerID = c.customerID
+	}
+	return strings.TrimSpace(customerID) + "/" + strings.ToLower(domain)
+}
+
+func (rc *responseCache) domain(key string) (*Domain, bool) {
+	if rc == nil {
+		return nil, false
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	d, ok := rc.domains[key]
+	if !ok {
+		return nil, false
+	}
+	return copyDomain(&d), true
+}
+
+func (rc *responseCache) setDomain(key string, d *Domain) {
+	if rc == nil {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	rc.domains[key] = *copyDomain(d)
+}
+
+// copyDomain copies the domain along with its nameservers and contacts so
+// that callers can't modify cached reads
+func copyDomain(d *Domain) *Domain {
+	c := *d
+	c.NameServers = append([]string(nil), d.NameServers...)
+	for _, contact := range []**Contact{&c.Registrant, &c.Admin, &c.Tech, &c.Billing} {
+		if *contact != nil {
+			copied := **contact
+			*contact = &copied
+		}
+	}
+	return &c
+}
+
+func (rc *responseCache) domainRecords(key string) ([]*DomainRecord, bool) {
+	if rc == nil {
+		return nil, false
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	cached, ok := rc.records[key]
+	if !ok {
+		return nil, false
+	}
+
+	records := make([]*DomainRecord, len(cached))
+	for i := range cached {
+		rec := cached[i]
+		records[i] = &rec
+	}
+	return records, true
+}
+
+func (rc *responseCache) setDomainRecords(key string, records []*DomainRecord) {
+	if rc == nil {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	cached := make([]DomainRecord, len(records))
+	for i, rec := range records {
+		cached[i] = *rec
+	}
+	rc.records[key] = cached
+}
+
+func (rc *responseCache) customer(shopperID string) (string, bool) {
+	if rc == nil {
+		return "", false
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	customerID, ok := rc.customers[shopperID]
+	return customerID, ok
+}
+
+func (rc *responseCache) setCustomer(shopperID, customerID string) {
+	if rc == nil {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	rc.customers[shopperID] = customerID
+}
+
+// invalidate drops every read of the domain
+func (rc *responseCache) invalidate(key string) {
+	if rc == nil {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	delete(rc.domains, key)
+	delete(rc.records, key)
+}
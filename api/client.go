@@ -2,16 +2,23 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
@@ -19,24 +26,146 @@ const (
 	headerAuthorization = "Authorization"
 	headerContent       = "Content-Type"
 	headerCustomerID    = "X-Shopper-Id"
+	headerRetryAfter    = "Retry-After"
+	headerUserAgent     = "User-Agent"
 	mediaTypeJSON       = "application/json"
 	rateLimit           = 1 * time.Second
+
+	// logSubsystem is the tflog subsystem of the API client. Its level can be
+	// set independently of the provider through logLevelEnv.
+	logSubsystem = "api"
+	logLevelEnv  = "TF_LOG_PROVIDER_GODADDY_API"
+
+	// maxAttempts bounds the number of times a throttled request is sent
+	maxAttempts = 3
 )
 
+// ssoKeyPattern matches the credentials of an Authorization header
+var ssoKeyPattern = regexp.MustCompile(`sso-key\s+[^\s"]+`)
+
+// sensitiveBodyPattern matches the auth code and the personal details of the
+// contacts in a response body, e.g. of the domain details
+var sensitiveBodyPattern = regexp.MustCompile(
+	`"(authCode|nameFirst|nameMiddle|nameLast|email|phone|fax|address1|address2|postalCode)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// DefaultTimeout bounds the duration of a single API request
+const DefaultTimeout = 30 * time.Second
+
+const (
+	// EnvProduction is the GoDaddy production environment
+	EnvProduction = "production"
+	// EnvOTE is the GoDaddy Operational Test Environment
+	EnvOTE = "ote"
+)
+
+// Environments maps the GoDaddy environments to the base url of their API.
+// API keys are issued for a single environment.
+var Environments = map[string]string{
+	EnvProduction: "https://api.godaddy.com",
+	EnvOTE:        "https://api.ote-godaddy.com",
+}
+
 // Client is a GoDaddy API client
 type Client struct {
 	baseURL    string
 	key        string
 	secret     string
-//	customerID string
+	customerID string
+	userAgent  string
+	transport  *http.Transport
+	limiter    *rateLimitedTransport
+	cache      *responseCache
 	client     *http.Client
 }
 
+// ClientOpt provides support for setting optional client parameters
+type ClientOpt func(*Client) error
+
+// WithProxy routes all requests through the provided proxy url instead of
+// the one configured in the HTTP(S)_PROXY environment variables
+func WithProxy(proxyURL string) ClientOpt {
+	return func(c *Client) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %s", err)
+		}
+		if u.Host == "" || u.Scheme == "" {
+			return fmt.Errorf("invalid proxy url. expected format: scheme://host[:port]")
+		}
+		c.transport.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithRootCAs trusts the PEM encoded certificates in addition to the system
+// certificate pool, e.g. for a TLS intercepting proxy
+func WithRootCAs(pem []byte) ClientOpt {
+	return func(c *Client) error {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("no PEM encoded certificates found in CA bundle")
+		}
+		c.transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+		return nil
+	}
+}
+
+// WithTimeout overrides the DefaultTimeout of a single API request
+func WithTimeout(timeout time.Duration) ClientOpt {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be a positive value")
+		}
+		c.client.Timeout = timeout
+		return nil
+	}
+}
+
+// WithCustomerID sets the customer used by requests that do not specify one
+func WithCustomerID(customerID string) ClientOpt {
+	return func(c *Client) error {
+		c.customerID = strings.TrimSpace(customerID)
+		return nil
+	}
+}
+
+// WithRateLimit overrides the maximum number of requests per minute
+func WithRateLimit(requestsPerMinute int) ClientOpt {
+	return func(c *Client) error {
+		if requestsPerMinute <= 0 {
+			return errors.New("rate limit must be a positive value")
+		}
+		c.limiter.interval = time.Minute / time.Duration(requestsPerMinute)
+		return nil
+	}
+}
+
+// WithCache keeps domain and record reads until the client writes to the
+// domain, saving requests when the same zone is read repeatedly
+func WithCache() ClientOpt {
+	return func(c *Client) error {
+		c.cache = newResponseCache()
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOpt {
+	return func(c *Client) error {
+		c.userAgent = strings.TrimSpace(userAgent)
+		return nil
+	}
+}
+
 // rateLimitedTransport throttles API calls to GoDaddy. It appears that
 // the rate limit is 60 requests per minute, which can be throttled and
 // enforced at a maximum of one request/second.
 type rateLimitedTransport struct {
 	delegate http.RoundTripper
+	interval time.Duration
 	throttle time.Time
 	sync.Mutex
 }
@@ -50,41 +179,77 @@ func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, err
 		time.Sleep(delta)
 	}
 
-	t.throttle = time.Now().Add(rateLimit)
+	t.throttle = time.Now().Add(t.interval)
 	return t.delegate.RoundTrip(req)
 }
 
 // NewClient constructs a new GoDaddy API client or an error if the supplied
 // input is invalid.
-func NewClient(baseURL, key, secret string) (*Client, error) {
+func NewClient(baseURL, key, secret string, opts ...ClientOpt) (*Client, error) {
 	baseURL, err := formatURL(baseURL)
 	if err != nil {
 		return nil, err
 	}
 
 	var netTransport = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
 		Dial: (&net.Dialer{
 			Timeout: 10 * time.Second,
 		}).Dial,
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
 
-	return &Client{
-		baseURL:    baseURL,
-		key:        strings.TrimSpace(key),
-		secret:     strings.TrimSpace(secret),
-//		customerID: strings.TrimSpace(customerID),
+	limiter := &rateLimitedTransport{
+		delegate: netTransport,
+		interval: rateLimit,
+		throttle: time.Now().Add(-(rateLimit)),
+	}
+
+	c := &Client{
+		baseURL:   baseURL,
+		key:       strings.TrimSpace(key),
+		secret:    strings.TrimSpace(secret),
+		transport: netTransport,
+		limiter:   limiter,
 		client: &http.Client{
-			Timeout: time.Second * 30,
-			Transport: &rateLimitedTransport{
-				delegate: netTransport,
-				throttle: time.Now().Add(-(rateLimit)),
-			},
+			Timeout:   DefaultTimeout,
+			Transport: limiter,
 		},
-	}, nil
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
-func (c *Client) execute(customerID string, req *http.Request, result interface{}) error {
+// logContext sets up the API logging subsystem, masking the credentials and
+// tagging every entry with the provided domain
+func (c *Client) logContext(ctx context.Context, domain string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv(logLevelEnv), tflog.WithRootFields())
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, strings.ToLower(headerAuthorization))
+	ctx = tflog.SubsystemMaskLogRegexes(ctx, logSubsystem, ssoKeyPattern, sensitiveBodyPattern)
+	for _, secret := range []string{c.key, c.secret} {
+		if secret != "" {
+			ctx = tflog.SubsystemMaskLogStrings(ctx, logSubsystem, secret)
+		}
+	}
+
+	if domain != "" {
+		ctx = tflog.SubsystemSetField(ctx, logSubsystem, "domain", domain)
+	}
+
+	return ctx
+}
+
+func (c *Client) execute(ctx context.Context, customerID string, req *http.Request, result interface{}) error {
+	req = req.WithContext(ctx)
+	if len(strings.TrimSpace(customerID)) == 0 {
+		customerID = c.customerID
+	}
 	if len(strings.TrimSpace(customerID)) > 0 {
 		req.Header.Set(headerCustomerID, customerID)
 	}
@@ -92,22 +257,71 @@ func (c *Client) execute(customerID string, req *http.Request, result interface{
 	req.Header.Set(headerAccept, mediaTypeJSON)
 	req.Header.Set(headerContent, mediaTypeJSON)
 	req.Header.Set(headerAuthorization, fmt.Sprintf("sso-key %s:%s", c.key, c.secret))
+	if c.userAgent != "" {
+		req.Header.Set(headerUserAgent, c.userAgent)
+	}
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return err
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+
+	var resp *http.Response
+	for attempt := 1; ; attempt++ {
+		fields["attempt"] = attempt
+
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			req.Body = body
+		}
+
+		tflog.SubsystemDebug(ctx, logSubsystem, "Sending request", fields)
+
+		start := time.Now()
+		var err error
+		resp, err = c.client.Do(req)
+		fields["duration"] = time.Since(start).String()
+		if err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Request failed", fields, map[string]interface{}{"error": err.Error()})
+			return err
+		}
+		fields["status"] = resp.StatusCode
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == maxAttempts {
+			break
+		}
+
+		delay := retryDelay(resp, attempt)
+		resp.Body.Close()
+		tflog.SubsystemWarn(ctx, logSubsystem, "Request throttled, retrying", fields, map[string]interface{}{"retry_in": delay.String()})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
 	}
 
 	defer resp.Body.Close()
 
-	if err = validate(resp); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
 		return err
 	}
 
-	buffer := new(bytes.Buffer)
-	body, err := io.ReadAll(io.TeeReader(resp.Body, buffer))
-	log.Printf("request \n %s %s", resp.Status, buffer)
-	if err != nil {
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received response", fields)
+	tflog.SubsystemTrace(ctx, logSubsystem, "Response body", map[string]interface{}{"body": string(body)})
+
+	if err = validate(resp, body); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			if env := environmentOf(c.baseURL); env != "" {
+				apiErr.Hint = fmt.Sprintf("check that the API key was issued for the %s environment", env)
+			}
+		}
 		return err
 	}
 
@@ -120,47 +334,80 @@ func (c *Client) execute(customerID string, req *http.Request, result interface{
 	return nil
 }
 
-func validate(resp *http.Response) error {
-	if resp.StatusCode < http.StatusBadRequest {
-		return nil
+// retryDelay honors the Retry-After header of a throttled response and falls
+// back to an exponential delay based on the rate limit
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get(headerRetryAfter)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
 	}
+	return rateLimit << attempt
+}
 
-	buffer := new(bytes.Buffer)
-	body, err := io.ReadAll(io.TeeReader(resp.Body, buffer))
-	log.Println(buffer)
-	if err != nil {
-		return err
+// APIError is an error response of the GoDaddy API
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Fields     []struct {
+		Code        string `json:"code"`
+		Message     string `json:"message"`
+		Path        string `json:"path"`
+		PathRelated string `json:"pathRelated"`
+	} `json:"fields"`
+	// Hint suggests how the error might be resolved
+	Hint string `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	var b bytes.Buffer
+	b.WriteString(fmt.Sprintf("[%d:%s] %s", e.StatusCode, e.Code, e.Message))
+
+	if len(e.Fields) > 0 {
+		b.WriteString(" (")
+		for i, field := range e.Fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(fmt.Sprintf("%s [%s]: %s", field.Path, field.Code, field.Message))
+		}
+		b.WriteString(")")
 	}
 
-	var errResp = struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-		Fields  []struct {
-			Code        string `json:"code"`
-			Message     string `json:"message"`
-			Path        string `json:"path"`
-			PathRelated string `json:"pathRelated"`
-		} `json:"fields"`
-	}{}
+	if e.Hint != "" {
+		b.WriteString(": ")
+		b.WriteString(e.Hint)
+	}
 
-	if err := json.Unmarshal(body, &errResp); err != nil {
-		return err
+	return b.String()
+}
+
+// IsNotFound reports whether the error is an API response for a missing resource
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func validate(resp *http.Response, body []byte) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
 	}
 
-	if len(errResp.Fields) == 0 {
-		return fmt.Errorf("[%d:%s] %s", resp.StatusCode, errResp.Code, errResp.Message)
+	errResp := &APIError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(body, errResp); err != nil {
+		errResp.Message = http.StatusText(resp.StatusCode)
 	}
 
-	var b bytes.Buffer
-	b.WriteString(fmt.Sprintf("[%d:%s] %s (", resp.StatusCode, errResp.Code, errResp.Message))
-	for i, field := range errResp.Fields {
-		if i > 0 {
-			b.WriteString(", ")
+	return errResp
+}
+
+// environmentOf names the GoDaddy environment served by the base url
+func environmentOf(baseURL string) string {
+	for env, envURL := range Environments {
+		if baseURL == envURL {
+			return env
 		}
-		b.WriteString(fmt.Sprintf("%s [%s]: %s", field.Path, field.Code, field.Message))
 	}
-	b.WriteString(")")
-	return fmt.Errorf("%s", b.String())
+	return ""
 }
 
 func formatURL(base string) (string, error) {
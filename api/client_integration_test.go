@@ -4,6 +4,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -29,7 +30,7 @@ func TestAuthFailure(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, client)
 
-	_, err = client.GetDomainRecords("", "bogus.com")
+	_, err = client.GetDomainRecords(context.Background(), "", "bogus.com")
 	assert.NotNil(t, err)
 }
 
@@ -78,7 +79,7 @@ func TestPatchRecords(t *testing.T) {
 }
 
 func getRecords(t *testing.T, client *Client, domain string) ([]*DomainRecord, error) {
-	records, err := client.GetDomainRecords("", domain, true)
+	records, err := client.GetDomainRecords(context.Background(), "", domain)
 	assert.Nil(t, err)
 	assert.NotNil(t, records)
 
@@ -94,7 +95,7 @@ func getRecords(t *testing.T, client *Client, domain string) ([]*DomainRecord, e
 }
 
 func patchRecords(t *testing.T, client *Client, domain string, records []*DomainRecord) error {
-	err := client.AddDomainRecords("", domain, records)
+	err := client.AddDomainRecords(context.Background(), "", domain, records)
 	assert.Nil(t, err)
 
 	return err
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteLogging(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprintf(w, `{"domain":"example.com","status":"ACTIVE","echo":%q}`, req.Header.Get(headerAuthorization))
+	}))
+	client.key, client.secret = "the-key", "the-secret"
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+
+	_, err := client.GetDomain(ctx, "", "example.com")
+	assert.Nil(t, err)
+
+	// decoding drains the output
+	logged := output.String()
+	assert.Contains(t, logged, "echo")
+	assert.NotContains(t, logged, "the-key")
+	assert.NotContains(t, logged, "the-secret")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, entries)
+
+	var response map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Received response" {
+			response = entry
+		}
+	}
+	if assert.NotNil(t, response) {
+		assert.Equal(t, "example.com", response["domain"])
+		assert.Equal(t, http.MethodGet, response["method"])
+		assert.Equal(t, "/v1/domains/example.com", response["path"])
+		assert.Equal(t, float64(http.StatusOK), response["status"])
+		assert.Equal(t, float64(1), response["attempt"])
+		assert.Contains(t, response, "duration")
+	}
+}
+
+func TestExecuteLoggingMasksResponseBody(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(w, `{"domain":"example.com","status":"ACTIVE","authCode":"Xy7#k\"Q2p",`+
+			`"contactRegistrant":{"nameFirst":"Jane","nameLast":"Doe","email":"jane@example.com","phone":"+1.4805058800",`+
+			`"addressMailing":{"address1":"14455 N Hayden Rd","city":"Scottsdale","country":"US"}}}`)
+	}))
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	ctx = tflog.NewSubsystem(ctx, logSubsystem)
+
+	authCode, err := client.GetAuthCode(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, `Xy7#k"Q2p`, authCode)
+
+	logged := output.String()
+	assert.Contains(t, logged, "Response body")
+	assert.Contains(t, logged, "Scottsdale")
+	for _, value := range []string{"Xy7#k", "Q2p", "Jane", "Doe", "jane@example.com", "4805058800", "Hayden"} {
+		assert.NotContains(t, logged, value)
+	}
+}
+
+func TestExecuteRetriesThrottledRequests(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set(headerRetryAfter, "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"code":"TOO_MANY_REQUESTS","message":"slow down"}`)
+			return
+		}
+		fmt.Fprint(w, `{"domain":"example.com","status":"ACTIVE"}`)
+	}))
+
+	domain, err := client.GetDomain(context.Background(), "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com", domain.Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestNewClientOptions(t *testing.T) {
+	client, err := NewClient("https://api.godaddy.com", "key", "secret",
+		WithProxy("http://proxy.internal:3128"),
+		WithTimeout(5*time.Second),
+		WithUserAgent("terraform-provider-godaddy/1.2.3"),
+		WithCustomerID(" 1234 "),
+		WithRateLimit(120),
+	)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	proxy, err := client.transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "api.godaddy.com"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "proxy.internal:3128", proxy.Host)
+	assert.Equal(t, 5*time.Second, client.client.Timeout)
+	assert.Equal(t, "terraform-provider-godaddy/1.2.3", client.userAgent)
+	assert.Equal(t, "1234", client.customerID)
+	assert.Equal(t, 500*time.Millisecond, client.limiter.interval)
+
+	var criteria = []struct {
+		Name string
+		Opt  ClientOpt
+	}{
+		{"Given a proxy without a scheme", WithProxy("proxy.internal:3128")},
+		{"Given a CA bundle without certificates", WithRootCAs([]byte("not a certificate"))},
+		{"Given a negative timeout", WithTimeout(-time.Second)},
+		{"Given a zero rate limit", WithRateLimit(0)},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := NewClient("https://api.godaddy.com", "key", "secret", test.Opt)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestExecuteUserAgent(t *testing.T) {
+	var userAgent string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userAgent = req.Header.Get(headerUserAgent)
+		fmt.Fprint(w, `{"domain":"example.com","status":"ACTIVE"}`)
+	}))
+	assert.Nil(t, WithUserAgent("terraform-provider-godaddy/1.2.3")(client))
+
+	_, err := client.GetDomain(context.Background(), "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "terraform-provider-godaddy/1.2.3", userAgent)
+}
+
+// rewriteTransport sends every request to the target server
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestUnauthorizedEnvironmentHint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code":"UNABLE_TO_AUTHENTICATE","message":"Unable to authenticate request"}`)
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	client := &Client{baseURL: srv.URL, client: srv.Client()}
+	_, err := client.GetDomains(context.Background(), "")
+	assert.EqualError(t, err, "[401:UNABLE_TO_AUTHENTICATE] Unable to authenticate request")
+
+	client = &Client{baseURL: Environments[EnvOTE], client: &http.Client{Transport: rewriteTransport{target}}}
+	_, err = client.GetDomains(context.Background(), "")
+	assert.EqualError(t, err, "[401:UNABLE_TO_AUTHENTICATE] Unable to authenticate request: "+
+		"check that the API key was issued for the ote environment")
+}
+
+func TestExecuteDefaultCustomer(t *testing.T) {
+	var customers []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		customers = append(customers, req.Header.Get(headerCustomerID))
+		fmt.Fprint(w, `{"domain":"example.com","status":"ACTIVE"}`)
+	}))
+	assert.Nil(t, WithCustomerID("1234")(client))
+
+	_, err := client.GetDomain(context.Background(), "", "example.com")
+	assert.Nil(t, err)
+	_, err = client.GetDomain(context.Background(), "5678", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1234", "5678"}, customers)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	pathDomainContacts = "%s/v1/domains/%s/contacts"
+
+	// VerificationApproved, VerificationPending and VerificationRejected are
+	// the states of the asynchronous verification of a domain's contacts
+	VerificationApproved = "APPROVED"
+	VerificationPending  = "PENDING"
+	VerificationRejected = "REJECTED"
+)
+
+var (
+	// phonePattern matches phone numbers the way GoDaddy expects them: the
+	// country calling code and the number separated by a dot, e.g. +1.4805058800
+	phonePattern = regexp.MustCompile(`^\+[0-9]{1,3}\.[0-9]{4,14}(x[0-9]{1,6})?$`)
+	// countryPattern matches ISO 3166-1 alpha-2 country codes
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Contact is a registrant, administrative, technical or billing contact of a domain
+type Contact struct {
+	NameFirst      string  `json:"nameFirst"`
+	NameMiddle     string  `json:"nameMiddle,omitempty"`
+	NameLast       string  `json:"nameLast"`
+	Organization   string  `json:"organization,omitempty"`
+	JobTitle       string  `json:"jobTitle,omitempty"`
+	Email          string  `json:"email"`
+	Phone          string  `json:"phone"`
+	Fax            string  `json:"fax,omitempty"`
+	AddressMailing Address `json:"addressMailing"`
+}
+
+// Address is the mailing address of a contact
+type Address struct {
+	Address1   string `json:"address1"`
+	Address2   string `json:"address2,omitempty"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+// DomainContacts are the contacts of a domain. Only the registrant is required.
+type DomainContacts struct {
+	Registrant *Contact `json:"contactRegistrant,omitempty"`
+	Admin      *Contact `json:"contactAdmin,omitempty"`
+	Tech       *Contact `json:"contactTech,omitempty"`
+	Billing    *Contact `json:"contactBilling,omitempty"`
+}
+
+// Verifications is the state of the asynchronous verification GoDaddy runs
+// after the registrant of a domain changed
+type Verifications struct {
+	DomainName Verification `json:"domainName"`
+	RealName   Verification `json:"realName"`
+}
+
+// Verification is the state of a single verification
+type Verification struct {
+	Status string `json:"status"`
+}
+
+// Validate reports the missing or malformed fields of the contact
+func (c *Contact) Validate() error {
+	var problems []string
+
+	for field, value := range map[string]string{
+		"nameFirst":  c.NameFirst,
+		"nameLast":   c.NameLast,
+		"email":      c.Email,
+		"phone":      c.Phone,
+		"address1":   c.AddressMailing.Address1,
+		"city":       c.AddressMailing.City,
+		"state":      c.AddressMailing.State,
+		"postalCode": c.AddressMailing.PostalCode,
+		"country":    c.AddressMailing.Country,
+	} {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", field))
+		}
+	}
+
+	if c.Email != "" {
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			problems = append(problems, fmt.Sprintf("email %s is not a valid address", c.Email))
+		}
+	}
+	for field, value := range map[string]string{"phone": c.Phone, "fax": c.Fax} {
+		if value != "" && !phonePattern.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s %s must be formatted as +<country code>.<number>, e.g. +1.4805058800", field, value))
+		}
+	}
+	if country := c.AddressMailing.Country; country != "" && !countryPattern.MatchString(country) {
+		problems = append(problems, fmt.Sprintf("country %s must be a two letter ISO 3166-1 code, e.g. US", country))
+	}
+
+	if len(problems) > 0 {
+		// map iteration is random, keep the message stable
+		sort.Strings(problems)
+		return fmt.Errorf("invalid contact: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Validate reports the missing or malformed contacts of the domain
+func (dc *DomainContacts) Validate() error {
+	if dc.Registrant == nil {
+		return fmt.Errorf("the registrant contact is required")
+	}
+
+	roles := []string{"registrant", "admin", "tech", "billing"}
+	for i, contact := range []*Contact{dc.Registrant, dc.Admin, dc.Tech, dc.Billing} {
+		if contact == nil {
+			continue
+		}
+		if err := contact.Validate(); err != nil {
+			return fmt.Errorf("%s: %s", roles[i], err)
+		}
+	}
+
+	return nil
+}
+
+// GetDomainContacts fetches the contacts of the provided domain along with
+// the state of their verification
+func (c *Client) GetDomainContacts(ctx context.Context, customerID, domain string) (*DomainContacts, *Verifications, error) {
+	d, err := c.GetDomain(ctx, customerID, domain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &d.DomainContacts, &d.Verifications, nil
+}
+
+// UpdateDomainContacts replaces the provided contacts of the domain. A new
+// registrant may have to be verified before the change takes effect.
+func (c *Client) UpdateDomainContacts(ctx context.Context, customerID, domain string, contacts *DomainContacts) error {
+	if err := contacts.Validate(); err != nil {
+		return err
+	}
+
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	msg, err := json.Marshal(contacts)
+	if err != nil {
+		return err
+	}
+
+	buffer := bytes.NewBuffer(msg)
+
+	domainURL := fmt.Sprintf(pathDomainContacts, c.baseURL, domain)
+	req, err := http.NewRequest(http.MethodPatch, domainURL, buffer)
+
+	if err != nil {
+		return err
+	}
+
+	if err = c.execute(ctx, customerID, req, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testContact() *Contact {
+	return &Contact{
+		NameFirst: "Jane",
+		NameLast:  "Doe",
+		Email:     "jane@example.com",
+		Phone:     "+1.4805058800",
+		AddressMailing: Address{
+			Address1:   "14455 N Hayden Rd",
+			City:       "Scottsdale",
+			State:      "AZ",
+			PostalCode: "85260",
+			Country:    "US",
+		},
+	}
+}
+
+func TestContactValidate(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		Modify   func(c *Contact)
+		Negative bool
+	}{
+		{"Given a valid contact", func(c *Contact) {}, false},
+		{"Given a phone extension and a fax", func(c *Contact) { c.Phone, c.Fax = "+44.2071234567x12", "+1.4805058801" }, false},
+		{"Given a missing last name", func(c *Contact) { c.NameLast = "" }, true},
+		{"Given a missing address", func(c *Contact) { c.AddressMailing = Address{} }, true},
+		{"Given an invalid email", func(c *Contact) { c.Email = "jane.example.com" }, true},
+		{"Given a phone without country code", func(c *Contact) { c.Phone = "480-505-8800" }, true},
+		{"Given an invalid fax", func(c *Contact) { c.Fax = "+1 480 505 8801" }, true},
+		{"Given a country name", func(c *Contact) { c.AddressMailing.Country = "United States" }, true},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			contact := testContact()
+			test.Modify(contact)
+
+			err := contact.Validate()
+			if test.Negative {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestDomainContactsValidate(t *testing.T) {
+	invalid := testContact()
+	invalid.Email = ""
+
+	assert.NotNil(t, (&DomainContacts{Admin: testContact()}).Validate())
+	assert.Nil(t, (&DomainContacts{Registrant: testContact(), Tech: testContact()}).Validate())
+
+	err := (&DomainContacts{Registrant: testContact(), Billing: invalid}).Validate()
+	assert.EqualError(t, err, "billing: invalid contact: email is required")
+}
+
+func TestUpdateDomainContacts(t *testing.T) {
+	var requests []string
+	var sent DomainContacts
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		w.Header().Set(headerContent, mediaTypeJSON)
+
+		switch req.Method {
+		case http.MethodPatch:
+			body, _ := io.ReadAll(req.Body)
+			json.Unmarshal(body, &sent)
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"domainId":          1,
+				"domain":            "example.com",
+				"status":            StatusActive,
+				"contactRegistrant": testContact(),
+				"verifications": map[string]interface{}{
+					"realName": map[string]string{"status": VerificationPending},
+				},
+			})
+		}
+	}))
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	contacts, verifications, err := client.GetDomainContacts(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, testContact(), contacts.Registrant)
+	assert.Nil(t, contacts.Admin)
+	assert.Equal(t, VerificationPending, verifications.RealName.Status)
+
+	// invalid contacts are never sent
+	err = client.UpdateDomainContacts(ctx, "", "example.com", &DomainContacts{Admin: testContact()})
+	assert.NotNil(t, err)
+
+	admin := testContact()
+	admin.NameFirst = "John"
+	err = client.UpdateDomainContacts(ctx, "", "example.com", &DomainContacts{Registrant: testContact(), Admin: admin})
+	assert.Nil(t, err)
+	assert.Equal(t, admin, sent.Admin)
+	assert.Nil(t, sent.Tech)
+
+	// the update drops the cached domain
+	_, _, err = client.GetDomainContacts(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"GET /v1/domains/example.com",
+		"PATCH /v1/domains/example.com/contacts",
+		"GET /v1/domains/example.com",
+	}, requests)
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	pathDomainV2     = "%s/v2/customers/%s/domains/%s?includes=dnssecRecords"
+	pathDomainDNSSEC = "%s/v2/customers/%s/domains/%s/dnssecRecords"
+
+	// ActionDNSSECCreate and ActionDNSSECDelete track DNSSEC record changes
+	ActionDNSSECCreate = "DNSSEC_CREATE"
+	ActionDNSSECDelete = "DNSSEC_DELETE"
+
+	maxDNSSECKeyTag = 65535
+)
+
+// DNSSECAlgorithms lists the signing algorithms GoDaddy accepts
+var DNSSECAlgorithms = []string{
+	"RSAMD5", "DSA", "RSASHA1", "DSA_NSEC3_SHA1", "RSASHA1_NSEC3_SHA1", "RSASHA256", "RSASHA512",
+	"ECC_GOST", "ECDSAP256SHA256", "ECDSAP384SHA384", "ED25519", "ED448",
+}
+
+// DNSSECDigestTypes lists the digest types GoDaddy accepts
+var DNSSECDigestTypes = []string{"SHA1", "SHA256", "GOST", "SHA384"}
+
+// DNSSECFlags lists the key flags GoDaddy accepts
+var DNSSECFlags = []string{"KSK", "ZSK"}
+
+var hexPattern = regexp.MustCompile(`^[0-9A-Fa-f]+$`)
+
+// DNSSECRecord is a DS record published at the registry, or the key it is
+// derived from
+type DNSSECRecord struct {
+	Algorithm        string `json:"algorithm"`
+	KeyTag           int    `json:"keyTag"`
+	DigestType       string `json:"digestType,omitempty"`
+	Digest           string `json:"digest,omitempty"`
+	Flags            string `json:"flags,omitempty"`
+	PublicKey        string `json:"publicKey,omitempty"`
+	MaxSignatureLife int    `json:"maxSignatureLife,omitempty"`
+}
+
+// Validate checks the record before it is sent to GoDaddy
+func (r DNSSECRecord) Validate() error {
+	if !containsString(DNSSECAlgorithms, r.Algorithm) {
+		return fmt.Errorf("invalid DNSSEC algorithm %q, expected one of %s", r.Algorithm, strings.Join(DNSSECAlgorithms, ", "))
+	}
+	if r.KeyTag < 0 || r.KeyTag > maxDNSSECKeyTag {
+		return fmt.Errorf("invalid DNSSEC key tag %d, expected a value between 0 and %d", r.KeyTag, maxDNSSECKeyTag)
+	}
+	if (r.Digest == "") != (r.DigestType == "") {
+		return fmt.Errorf("DNSSEC record %d requires both a digest and a digest type", r.KeyTag)
+	}
+	if r.DigestType != "" && !containsString(DNSSECDigestTypes, r.DigestType) {
+		return fmt.Errorf("invalid DNSSEC digest type %q, expected one of %s", r.DigestType, strings.Join(DNSSECDigestTypes, ", "))
+	}
+	if r.Digest != "" && !hexPattern.MatchString(r.Digest) {
+		return fmt.Errorf("invalid DNSSEC digest for key tag %d, expected a hexadecimal string", r.KeyTag)
+	}
+	if r.Flags != "" && !containsString(DNSSECFlags, r.Flags) {
+		return fmt.Errorf("invalid DNSSEC flags %q, expected one of %s", r.Flags, strings.Join(DNSSECFlags, ", "))
+	}
+	if r.Digest == "" && r.PublicKey == "" {
+		return fmt.Errorf("DNSSEC record %d requires a digest or a public key", r.KeyTag)
+	}
+	if r.MaxSignatureLife < 0 {
+		return fmt.Errorf("invalid DNSSEC max signature life %d, expected a positive value", r.MaxSignatureLife)
+	}
+	return nil
+}
+
+// normalize makes records that only differ by the case of their digest equal
+func (r DNSSECRecord) normalize() DNSSECRecord {
+	r.Digest = strings.ToUpper(r.Digest)
+	return r
+}
+
+// DiffDNSSECRecords lists the records to add and to remove to turn the
+// current records into the desired ones
+func DiffDNSSECRecords(current, desired []DNSSECRecord) ([]DNSSECRecord, []DNSSECRecord) {
+	currentSet := make(map[DNSSECRecord]struct{}, len(current))
+	for _, r := range current {
+		currentSet[r.normalize()] = struct{}{}
+	}
+	desiredSet := make(map[DNSSECRecord]struct{}, len(desired))
+	for _, r := range desired {
+		desiredSet[r.normalize()] = struct{}{}
+	}
+
+	add := make([]DNSSECRecord, 0)
+	for _, r := range desired {
+		if _, ok := currentSet[r.normalize()]; !ok {
+			add = append(add, r)
+		}
+	}
+
+	remove := make([]DNSSECRecord, 0)
+	for _, r := range current {
+		if _, ok := desiredSet[r.normalize()]; !ok {
+			remove = append(remove, r)
+		}
+	}
+
+	return add, remove
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDNSSECRecords fetches the DNSSEC records of the domain
+func (c *Client) GetDNSSECRecords(ctx context.Context, customerID, domain string) ([]DNSSECRecord, error) {
+	ctx = c.logContext(ctx, domain)
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	domainURL := fmt.Sprintf(pathDomainV2, c.baseURL, v2CustomerID, domain)
+	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := &struct {
+		DNSSECRecords []DNSSECRecord `json:"dnssecRecords"`
+	}{}
+	if err := c.execute(ctx, customerID, req, d); err != nil {
+		return nil, err
+	}
+
+	if d.DNSSECRecords == nil {
+		return make([]DNSSECRecord, 0), nil
+	}
+	return d.DNSSECRecords, nil
+}
+
+// AddDNSSECRecords adds the DNSSEC records to the domain. GoDaddy applies them
+// asynchronously, see WaitForAction with ActionDNSSECCreate.
+func (c *Client) AddDNSSECRecords(ctx context.Context, customerID, domain string, records []DNSSECRecord) error {
+	ctx = c.logContext(ctx, domain)
+
+	for _, r := range records {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return c.writeDNSSECRecords(ctx, http.MethodPatch, customerID, domain, records)
+}
+
+// RemoveDNSSECRecords removes the DNSSEC records from the domain. GoDaddy
+// applies the removal asynchronously, see WaitForAction with
+// ActionDNSSECDelete.
+func (c *Client) RemoveDNSSECRecords(ctx context.Context, customerID, domain string, records []DNSSECRecord) error {
+	ctx = c.logContext(ctx, domain)
+	return c.writeDNSSECRecords(ctx, http.MethodDelete, customerID, domain, records)
+}
+
+func (c *Client) writeDNSSECRecords(ctx context.Context, method, customerID, domain string, records []DNSSECRecord) error {
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	msg, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	buffer := bytes.NewBuffer(msg)
+
+	dnssecURL := fmt.Sprintf(pathDomainDNSSEC, c.baseURL, v2CustomerID, domain)
+	req, err := http.NewRequest(method, dnssecURL, buffer)
+
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, customerID, req, nil)
+}
@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testDNSSECRecord() DNSSECRecord {
+	return DNSSECRecord{
+		Algorithm:  "ECDSAP256SHA256",
+		KeyTag:     2371,
+		DigestType: "SHA256",
+		Digest:     "C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D8CBFB4DAFD5DB2F3E8CC",
+	}
+}
+
+func TestDNSSECRecordValidate(t *testing.T) {
+	cases := map[string]struct {
+		update func(r *DNSSECRecord)
+		valid  bool
+	}{
+		"Given a DS record":            {func(r *DNSSECRecord) {}, true},
+		"Given a key":                  {func(r *DNSSECRecord) { r.Digest, r.DigestType, r.Flags, r.PublicKey = "", "", "KSK", "mdsswUyr3DPW" }, true},
+		"Given an unknown algorithm":   {func(r *DNSSECRecord) { r.Algorithm = "13" }, false},
+		"Given an invalid key tag":     {func(r *DNSSECRecord) { r.KeyTag = 70000 }, false},
+		"Given a digest without type":  {func(r *DNSSECRecord) { r.DigestType = "" }, false},
+		"Given a non hex digest":       {func(r *DNSSECRecord) { r.Digest = "not-hex" }, false},
+		"Given unknown flags":          {func(r *DNSSECRecord) { r.Flags = "SEP" }, false},
+		"Given neither digest nor key": {func(r *DNSSECRecord) { r.Digest, r.DigestType = "", "" }, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := testDNSSECRecord()
+			tc.update(&r)
+			if tc.valid {
+				assert.Nil(t, r.Validate())
+			} else {
+				assert.NotNil(t, r.Validate())
+			}
+		})
+	}
+}
+
+func TestDiffDNSSECRecords(t *testing.T) {
+	kept := testDNSSECRecord()
+	old := testDNSSECRecord()
+	old.KeyTag = 1000
+	added := testDNSSECRecord()
+	added.KeyTag = 3000
+
+	// the case of the digest does not matter
+	lower := kept
+	lower.Digest = "c988ec423e3880eb8dd8a46fe06ca230ee23f35b578d8cbfb4dafd5db2f3e8cc"
+
+	add, remove := DiffDNSSECRecords([]DNSSECRecord{kept, old}, []DNSSECRecord{lower, added})
+	assert.Equal(t, []DNSSECRecord{added}, add)
+	assert.Equal(t, []DNSSECRecord{old}, remove)
+}
+
+func TestDNSSECRecords(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	var requests, bodies []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		bodies = append(bodies, string(body))
+		w.Header().Set(headerContent, mediaTypeJSON)
+
+		switch req.URL.Path {
+		case "/v1/shoppers/467624111":
+			fmt.Fprint(w, `{"customerId":"01804e6f"}`)
+		case "/v2/customers/01804e6f/domains/example.com":
+			assert.Equal(t, "dnssecRecords", req.URL.Query().Get("includes"))
+			fmt.Fprint(w, `{"domain":"example.com","dnssecRecords":[{"algorithm":"ECDSAP256SHA256","keyTag":2371,"digestType":"SHA256","digest":"C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D8CBFB4DAFD5DB2F3E8CC"}]}`)
+		case "/v2/customers/01804e6f/domains/example.com/dnssecRecords":
+			w.WriteHeader(http.StatusAccepted)
+		case "/v2/customers/01804e6f/domains/example.com/actions/DNSSEC_CREATE":
+			fmt.Fprint(w, `{"type":"DNSSEC_CREATE","status":"SUCCESS"}`)
+		}
+	}))
+	client.customerID = "467624111"
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	records, err := client.GetDNSSECRecords(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, []DNSSECRecord{testDNSSECRecord()}, records)
+
+	assert.Nil(t, client.AddDNSSECRecords(ctx, "", "example.com", records))
+	assert.Equal(t, "PATCH /v2/customers/01804e6f/domains/example.com/dnssecRecords", requests[2])
+	assert.JSONEq(t, `[{"algorithm":"ECDSAP256SHA256","keyTag":2371,"digestType":"SHA256","digest":"C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D8CBFB4DAFD5DB2F3E8CC"}]`, bodies[2])
+
+	_, err = client.WaitForAction(ctx, "", "example.com", ActionDNSSECCreate, time.Time{}, time.Second)
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.RemoveDNSSECRecords(ctx, "", "example.com", records))
+	assert.Equal(t, "DELETE /v2/customers/01804e6f/domains/example.com/dnssecRecords", requests[4])
+	assert.Equal(t, bodies[2], bodies[4])
+}
+
+func TestAddDNSSECRecordsInvalid(t *testing.T) {
+	var requests int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+	}))
+
+	record := testDNSSECRecord()
+	record.Algorithm = "13"
+	assert.NotNil(t, client.AddDNSSECRecords(context.Background(), "467624111", "example.com", []DNSSECRecord{record}))
+	assert.Zero(t, requests)
+}
+
+func TestWaitForActionIgnoresEarlierAction(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	cases := map[string]struct {
+		skew time.Duration
+	}{
+		"Given a server clock in sync":        {0},
+		"Given a server clock ahead":          {time.Hour},
+		"Given a server clock behind":         {-time.Hour},
+		"Given a server clock slightly ahead": {2 * time.Second},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// the times are those of the server, the change being requested a second after the previous one
+			now := time.Now().Add(tc.skew)
+			earlier := now.Add(-time.Second).UTC().Format(time.RFC3339)
+			later := now.UTC().Format(time.RFC3339)
+
+			// the action of a previous change is reported until this one is recorded
+			responses := []string{
+				`{"type":"DNSSEC_DELETE","status":"SUCCESS","createdAt":"` + earlier + `"}`,
+				`{"type":"DNSSEC_DELETE","status":"SUCCESS","createdAt":"` + earlier + `"}`,
+				`{"type":"DNSSEC_DELETE","status":"PENDING","createdAt":"` + later + `"}`,
+				`{"type":"DNSSEC_DELETE","status":"SUCCESS","createdAt":"` + later + `"}`,
+			}
+			var reads int
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set(headerContent, mediaTypeJSON)
+
+				switch req.URL.Path {
+				case "/v1/shoppers/467624111":
+					fmt.Fprint(w, `{"customerId":"01804e6f"}`)
+				case "/v2/customers/01804e6f/domains/example.com/actions/DNSSEC_DELETE":
+					fmt.Fprint(w, responses[reads])
+					reads++
+				}
+			}))
+			ctx := context.Background()
+
+			after, err := client.LastActionTime(ctx, "467624111", "example.com", ActionDNSSECDelete)
+			assert.Nil(t, err)
+
+			action, err := client.WaitForAction(ctx, "467624111", "example.com", ActionDNSSECDelete, after, time.Second)
+			assert.Nil(t, err)
+			assert.Equal(t, ActionSuccess, action.Status)
+			assert.Equal(t, len(responses), reads)
+		})
+	}
+}
+
+func TestLastActionTimeWithoutAction(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(headerContent, mediaTypeJSON)
+		if req.URL.Path == "/v1/shoppers/467624111" {
+			fmt.Fprint(w, `{"customerId":"01804e6f"}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"NOT_FOUND","message":"Action not found"}`)
+	}))
+
+	after, err := client.LastActionTime(context.Background(), "467624111", "example.com", ActionDNSSECCreate)
+	assert.Nil(t, err)
+	assert.True(t, after.IsZero())
+}
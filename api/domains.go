@@ -2,30 +2,48 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	defaultLimit = 500
+	// maxRecordPages guards against paging forever through a misbehaving API
+	maxRecordPages = 100
 
 	pathDomainRecords       = "%s/v1/domains/%s/records?limit=%d&offset=%d"
 	pathDomainRecordsAdd    = "%s/v1/domains/%s/records"
 	pathDomainRecordsUpdate = "%s/v1/domains/%s/records/%s/%s"
 	pathDomainRecordsByType = "%s/v1/domains/%s/records/%s"
 	pathDomains             = "%s/v1/domains/%s"
+	pathDomainsLimit        = "%s/v1/domains?limit=%d"
 
 	//to use v2 api
-	shoppers               = "%s/v1/shoppers/%s?includes=customerId"
 	pathDomainsNameServers = "%s/v2/customers/%s/domains/%s/nameServers"
 )
 
+// ErrTimeout is returned once a pending domain did not complete its changes in time
+var ErrTimeout = errors.New("timeout")
+
+// pendingDelayMin and pendingDelayMax bound the delay between two polls of a
+// pending domain
+var (
+	pendingDelayMin = 3 * time.Second
+	pendingDelayMax = 30 * time.Second
+)
+
 // GetDomains fetches the details for the provided domain
-func (c *Client) GetDomains(customerID string) ([]Domain, error) {
+func (c *Client) GetDomains(ctx context.Context, customerID string) ([]Domain, error) {
+	ctx = c.logContext(ctx, "")
+
 	domainURL := fmt.Sprintf(pathDomains, c.baseURL, "")
 	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
 
@@ -34,47 +52,156 @@ func (c *Client) GetDomains(customerID string) ([]Domain, error) {
 	}
 
 	var d []Domain
-	if err := c.execute(customerID, req, &d); err != nil {
+	if err := c.execute(ctx, customerID, req, &d); err != nil {
 		return nil, err
 	}
 
 	return d, nil
 }
 
-// GetDomain fetches the details for the provided domain
-func (c *Client) GetDomain(customerID, domain string) (*Domain, error) {
-	domainURL := fmt.Sprintf(pathDomains, c.baseURL, domain)
+// CheckCredentials performs a single inexpensive authenticated request and
+// reports whether the credentials give access to any domain
+func (c *Client) CheckCredentials(ctx context.Context, customerID string) (bool, error) {
+	ctx = c.logContext(ctx, "")
+
+	domainURL := fmt.Sprintf(pathDomainsLimit, c.baseURL, 1)
 	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
 
+	if err != nil {
+		return false, err
+	}
+
+	var d []Domain
+	if err := c.execute(ctx, customerID, req, &d); err != nil {
+		return false, err
+	}
+
+	return len(d) > 0, nil
+}
+
+// GetDomain fetches the details for the provided domain without waiting for
+// pending changes to complete
+func (c *Client) GetDomain(ctx context.Context, customerID, domain string) (*Domain, error) {
+	ctx = c.logContext(ctx, domain)
+
+	key := c.cacheKey(customerID, domain)
+	if d, ok := c.cache.domain(key); ok {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Using cached domain")
+		return d, nil
+	}
+
+	d, err := c.fetchDomain(ctx, customerID, domain)
 	if err != nil {
 		return nil, err
 	}
 
-	d := new(Domain)
+	// a pending domain is about to change, keep asking the API for it
+	if !d.IsPending() {
+		c.cache.setDomain(key, d)
+	}
+	return d, nil
+}
+
+// WaitForDomain fetches the details for the provided domain once GoDaddy has
+// completed its pending changes. The domain is polled with an increasing
+// delay until the timeout expires.
+func (c *Client) WaitForDomain(ctx context.Context, customerID, domain string, timeout time.Duration) (*Domain, error) {
+	ctx = c.logContext(ctx, domain)
+
+	// only domains without pending changes are cached
+	if d, ok := c.cache.domain(c.cacheKey(customerID, domain)); ok {
+		return d, nil
+	}
+
+	return c.pollDomain(ctx, customerID, domain, timeout, false)
+}
+
+// pollDomain fetches the domain until it leaves its pending status or the
+// timeout expires. A domain that is not found yet is considered pending if
+// missingPending is set, e.g. while a purchase is processed.
+func (c *Client) pollDomain(ctx context.Context, customerID, domain string, timeout time.Duration, missingPending bool) (*Domain, error) {
+	var d *Domain
+	err := poll(ctx, domain, timeout, func() (string, error) {
+		var err error
+		d, err = c.fetchDomain(ctx, customerID, domain)
+
+		switch {
+		case err == nil && !d.IsPending():
+			c.cache.setDomain(c.cacheKey(customerID, domain), d)
+			return "", nil
+		case err == nil:
+			return d.Status, nil
+		case missingPending && IsNotFound(err):
+			return "NOT_FOUND", nil
+		default:
+			return "", err
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// poll calls check with an increasing delay until it reports no pending
+// status, fails or the timeout expires
+func poll(ctx context.Context, domain string, timeout time.Duration, check func() (string, error)) error {
+	deadline := time.Now().Add(timeout)
+	delay := pendingDelayMin
 	for {
-		if err := c.execute(customerID, req, &d); err != nil {
-			return nil, err
+		status, err := check()
+		if err != nil || status == "" {
+			return err
 		}
 
-		if !strings.Contains(d.Status, "PENDING") {
-			break
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("%w after %s waiting for %s to leave status %s", ErrTimeout, timeout, domain, status)
 		}
 
-		time.Sleep(3 * time.Second)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Waiting for pending domain", map[string]interface{}{
+			"status":   status,
+			"retry_in": delay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > pendingDelayMax {
+			delay = pendingDelayMax
+		}
+	}
+}
+
+func (c *Client) fetchDomain(ctx context.Context, customerID, domain string) (*Domain, error) {
+	domainURL := fmt.Sprintf(pathDomains, c.baseURL, domain)
+	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := new(Domain)
+	if err := c.execute(ctx, customerID, req, &d); err != nil {
+		return nil, err
 	}
 
 	return d, nil
 }
 
-// UpdateNSDomain ...
-func (c *Client) UpdateNSDomain(ns []string, customerID, domain string) error {
-	t := &struct {
-		NameServers []string `json:"nameServers"`
-	}{
-		NameServers: ns,
-	}
+// UpdateNSDomain switches the domain to the provided nameservers
+func (c *Client) UpdateNSDomain(ctx context.Context, ns []string, customerID, domain string) error {
+	return c.UpdateDomain(ctx, customerID, domain, &DomainUpdate{NameServers: ns})
+}
+
+// UpdateDomain changes the settings of the domain that are set in the update
+func (c *Client) UpdateDomain(ctx context.Context, customerID, domain string, update *DomainUpdate) error {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
 
-	msg, err := json.Marshal(t)
+	msg, err := json.Marshal(update)
 	if err != nil {
 		return err
 	}
@@ -88,42 +215,101 @@ func (c *Client) UpdateNSDomain(ns []string, customerID, domain string) error {
 		return err
 	}
 
-	if err = c.execute(customerID, req, nil); err != nil {
+	if err = c.execute(ctx, customerID, req, nil); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// LockDomain locks the domain against transfers to another registrar, or
+// unlocks it so it can be transferred
+func (c *Client) LockDomain(ctx context.Context, customerID, domain string, locked bool) error {
+	return c.UpdateDomain(ctx, customerID, domain, &DomainUpdate{Locked: &locked})
+}
+
+// GetAuthCode fetches the code the registrar a domain is transferred to
+// needs to authorize the transfer. The domain is fetched anew since GoDaddy
+// may generate another code once the domain is unlocked.
+func (c *Client) GetAuthCode(ctx context.Context, customerID, domain string) (string, error) {
+	ctx = c.logContext(ctx, domain)
+
+	d, err := c.fetchDomain(ctx, customerID, domain)
+	if err != nil {
+		return "", err
+	}
+
+	if d.AuthCode == "" {
+		return "", fmt.Errorf("no auth code available for %s, the domain may not be transferable yet", domain)
+	}
+
+	return d.AuthCode, nil
+}
+
 // GetDomainRecords fetches all existing records for the provided domain
-func (c *Client) GetDomainRecords(customerID, domain string) ([]*DomainRecord, error) {
-	offset := 1
+func (c *Client) GetDomainRecords(ctx context.Context, customerID, domain string) ([]*DomainRecord, error) {
+	ctx = c.logContext(ctx, domain)
+
+	key := c.cacheKey(customerID, domain)
+	if records, ok := c.cache.domainRecords(key); ok {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Using cached records")
+		return records, nil
+	}
+
+	records, err := c.fetchDomainRecords(ctx, customerID, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.setDomainRecords(key, records)
+	return records, nil
+}
+
+// fetchDomainRecords pages through the records of the domain, bypassing the cache
+func (c *Client) fetchDomainRecords(ctx context.Context, customerID, domain string) ([]*DomainRecord, error) {
+	// The records endpoint documents offset as the "number of results to skip
+	// for pagination" and limit as the "maximum number of items to return"
+	// (https://developer.godaddy.com/doc/endpoint/domains#/v1/recordGet), a
+	// page shorter than the limit is the last one.
 	records := make([]*DomainRecord, 0)
-	for {
+	for pages := 0; ; pages++ {
+		if pages == maxRecordPages {
+			return nil, fmt.Errorf("more than %d pages of records returned for %s", maxRecordPages, domain)
+		}
+
 		page := make([]*DomainRecord, 0)
-		domainURL := fmt.Sprintf(pathDomainRecords, c.baseURL, domain, defaultLimit, offset)
+		domainURL := fmt.Sprintf(pathDomainRecords, c.baseURL, domain, defaultLimit, len(records))
 		req, err := http.NewRequest(http.MethodGet, domainURL, nil)
 
 		if err != nil {
 			return nil, err
 		}
 
-		if err := c.execute(customerID, req, &page); err != nil {
+		if err := c.execute(ctx, customerID, req, &page); err != nil {
 			return nil, err
 		}
-		if len(page) == 0 {
-			break
+
+		// a page starting like the previous one means the offset was ignored
+		if pages > 0 && len(page) > 0 && reflect.DeepEqual(page[0], records[len(records)-defaultLimit]) {
+			return nil, fmt.Errorf("records of %s at offset %d repeat the previous page, the offset was not applied", domain, len(records))
 		}
-		offset += 1
 		records = append(records, page...)
+		if len(page) < defaultLimit {
+			break
+		}
 	}
 
 	return records, nil
 }
 
-// AddDomainRecords adds records without affecting existing ones on the provided domain
-func (c *Client) AddDomainRecords(customerID, domain string, records []*DomainRecord) error {
-	for t := range supportedTypes {
+// AddDomainRecords adds records without affecting existing ones on the provided domain.
+// Record types are written in addOrder.
+func (c *Client) AddDomainRecords(ctx context.Context, customerID, domain string, records []*DomainRecord) error {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	for _, rt := range addOrder {
+		t := rt.String()
 		typeRecords := c.domainRecordsOfType(t, records)
 		if IsDisallowed(t, typeRecords) {
 			continue
@@ -136,8 +322,10 @@ func (c *Client) AddDomainRecords(customerID, domain string, records []*DomainRe
 
 		buffer := bytes.NewBuffer(msg)
 		domainURL := fmt.Sprintf(pathDomainRecordsAdd, c.baseURL, domain)
-		log.Println(domainURL)
-		log.Println(buffer)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Adding records", map[string]interface{}{
+			"type":    t,
+			"records": len(typeRecords),
+		})
 
 		// set method to patch to only add records
 		// for more info check: https://developer.godaddy.com/doc/endpoint/domains#/v1/recordAdd
@@ -146,7 +334,7 @@ func (c *Client) AddDomainRecords(customerID, domain string, records []*DomainRe
 			return err
 		}
 
-		if err := c.execute(customerID, req, nil); err != nil {
+		if err := c.execute(ctx, customerID, req, nil); err != nil {
 			return err
 		}
 	}
@@ -154,42 +342,92 @@ func (c *Client) AddDomainRecords(customerID, domain string, records []*DomainRe
 	return nil
 }
 
-// ReplaceDomainRecords overwrites all existing records with the ones provided
-func (c *Client) ReplaceDomainRecords(customerID, domain string, records []*DomainRecord) error {
-	for t := range supportedTypes {
+// ReplaceDomainRecords overwrites all existing records with the ones provided.
+// The zone is captured before any record type is written and, should one of
+// the writes fail, the types changed so far are restored from that snapshot.
+// Record types are written in replaceOrder.
+func (c *Client) ReplaceDomainRecords(ctx context.Context, customerID, domain string, records []*DomainRecord) error {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	snapshot, err := c.fetchDomainRecords(ctx, customerID, domain)
+	if err != nil {
+		return fmt.Errorf("couldn't capture %s records before replacing them: %s", domain, err.Error())
+	}
+
+	changed := make([]string, 0)
+	for _, rt := range replaceOrder {
+		t := rt.String()
 		typeRecords := c.domainRecordsOfType(t, records)
 		if IsDisallowed(t, typeRecords) {
 			continue
 		}
 
-		msg, err := json.Marshal(typeRecords)
-		if err != nil {
-			return err
+		if err := c.replaceDomainRecordsOfType(ctx, customerID, domain, t, typeRecords); err != nil {
+			return c.rollbackDomainRecords(ctx, customerID, domain, snapshot, changed, t, err)
 		}
+		changed = append(changed, t)
+	}
 
-		domainURL := fmt.Sprintf(pathDomainRecordsByType, c.baseURL, domain, t)
-		buffer := bytes.NewBuffer(msg)
+	return nil
+}
 
-		log.Println(domainURL)
-		log.Println(buffer)
+// rollbackDomainRecords restores the changed record types from the snapshot,
+// most recent first, and reports the outcome as a ReplaceError
+func (c *Client) rollbackDomainRecords(ctx context.Context, customerID, domain string, snapshot []*DomainRecord, changed []string, failed string, cause error) error {
+	replaceErr := &ReplaceError{
+		Domain:  domain,
+		Changed: changed,
+		Failed:  []string{failed},
+		Err:     cause,
+	}
 
-		// set method to put to replace all existing records
-		// for more info check: https://developer.godaddy.com/doc/endpoint/domains#/v1/recordReplaceType
-		req, err := http.NewRequest(http.MethodPut, domainURL, buffer)
-		if err != nil {
-			return err
+	for i := len(changed) - 1; i >= 0; i-- {
+		t := changed[i]
+		tflog.SubsystemWarn(ctx, logSubsystem, "Rolling back records", map[string]interface{}{"type": t})
+		if err := c.replaceDomainRecordsOfType(ctx, customerID, domain, t, c.domainRecordsOfType(t, snapshot)); err != nil {
+			tflog.SubsystemError(ctx, logSubsystem, "Rolling back records failed", map[string]interface{}{
+				"type":  t,
+				"error": err.Error(),
+			})
+			replaceErr.Failed = append(replaceErr.Failed, t)
+			continue
 		}
+		replaceErr.RolledBack = append(replaceErr.RolledBack, t)
+	}
 
-		if err := c.execute(customerID, req, nil); err != nil {
-			return err
-		}
+	return replaceErr
+}
+
+func (c *Client) replaceDomainRecordsOfType(ctx context.Context, customerID, domain, t string, records []*DomainRecord) error {
+	msg, err := json.Marshal(records)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	domainURL := fmt.Sprintf(pathDomainRecordsByType, c.baseURL, domain, t)
+	buffer := bytes.NewBuffer(msg)
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Replacing records", map[string]interface{}{
+		"type":    t,
+		"records": len(records),
+	})
+
+	// set method to put to replace all existing records
+	// for more info check: https://developer.godaddy.com/doc/endpoint/domains#/v1/recordReplaceType
+	req, err := http.NewRequest(http.MethodPut, domainURL, buffer)
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, customerID, req, nil)
 }
 
 // AddDomainRecords adds records without affecting existing ones on the provided domain
-func (c *Client) UpdateDomainRecords(customerID, domain string, records []*DomainRecord) error {
+func (c *Client) UpdateDomainRecords(ctx context.Context, customerID, domain string, records []*DomainRecord) error {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
 	for _, rec := range records {
 		// typeRecords := c.domainRecordsOfType(t, records)
 		t := rec.Type
@@ -204,15 +442,17 @@ func (c *Client) UpdateDomainRecords(customerID, domain string, records []*Domai
 
 		buffer := bytes.NewBuffer(msg)
 		domainURL := fmt.Sprintf(pathDomainRecordsUpdate, c.baseURL, domain, t, rec.Name)
-		log.Println(domainURL)
-		log.Println(buffer)
+		tflog.SubsystemDebug(ctx, logSubsystem, "Updating record", map[string]interface{}{
+			"type": t,
+			"name": rec.Name,
+		})
 
 		req, err := http.NewRequest(http.MethodPut, domainURL, buffer)
 		if err != nil {
 			return err
 		}
 
-		if err := c.execute(customerID, req, nil); err != nil {
+		if err := c.execute(ctx, customerID, req, nil); err != nil {
 			return err
 		}
 	}
@@ -220,43 +460,6 @@ func (c *Client) UpdateDomainRecords(customerID, domain string, records []*Domai
 	return nil
 }
 
-// // GetDomainRecords fetches all existing records for the provided domain
-// func (c *Client) GetShoppers(customerID string) (string, error) {
-
-// 	page := make([]*DomainRecord, 0)
-// 	domainURL := fmt.Sprintf(shoppers, c.baseURL, c.customerID)
-// 	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
-
-// 	if err != nil {
-// 		return "", err
-// 	}
-
-// 	if err := c.execute(customerID, req, &page); err != nil {
-// 		return "", err
-// 	}
-
-// 	return "", nil
-// }
-
-// func (c *Client) GetPoll(domain string) (string, error) {
-
-// 	page := make([]*DomainRecord, 0)
-// 	domainURL := fmt.Sprintf("%s/v2/customers/%s/domains/%s/actions", c.baseURL, "01804e6f-6562-4304-be20-6563ee6d7573", domain)
-// 	req, err := http.NewRequest(http.MethodGet, domainURL, nil)
-
-// 	if err != nil {
-// 		return "", err
-// 	}
-
-// 	//req.Header.Set("x-shopper-id", "467624111")
-
-// 	if err := c.execute("467624111", req, &page); err != nil {
-// 		return "", err
-// 	}
-
-// 	return "", nil
-// }
-
 // // AddNSRecords adds NS records
 // func (c *Client) UpdateDomainInfo(domain string, ns []string) error {
 // 	t := &struct {
@@ -0,0 +1,518 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// zoneServer is a stand-in for the GoDaddy records API, serving a single zone
+// and recording every write it receives
+type zoneServer struct {
+	sync.Mutex
+	records []*DomainRecord
+	fail    map[string]bool
+	writes  []string
+	reads   int
+}
+
+func (z *zoneServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	z.Lock()
+	defer z.Unlock()
+
+	w.Header().Set(headerContent, mediaTypeJSON)
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	switch {
+	case req.Method == http.MethodGet && len(parts) == 4:
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+		z.reads++
+
+		page := []*DomainRecord{}
+		if offset < len(z.records) {
+			end := offset + limit
+			if limit <= 0 || end > len(z.records) {
+				end = len(z.records)
+			}
+			page = z.records[offset:end]
+		}
+		json.NewEncoder(w).Encode(page)
+	case req.Method == http.MethodPatch && len(parts) == 4:
+		var recs []*DomainRecord
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &recs)
+
+		t := ""
+		if len(recs) > 0 {
+			t = recs[0].Type
+		}
+		z.writes = append(z.writes, req.Method+" "+t)
+		z.records = append(z.records, recs...)
+	case req.Method == http.MethodPut && len(parts) == 5:
+		t := parts[4]
+		z.writes = append(z.writes, req.Method+" "+t)
+		if z.fail[t] {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprintf(w, `{"code":"INVALID_BODY","message":"%s records rejected"}`, t)
+			return
+		}
+
+		var recs []*DomainRecord
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &recs)
+
+		kept := make([]*DomainRecord, 0)
+		for _, rec := range z.records {
+			if rec.Type != t {
+				kept = append(kept, rec)
+			}
+		}
+		for _, rec := range recs {
+			rec.Type = t
+			kept = append(kept, rec)
+		}
+		z.records = kept
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"NOT_FOUND","message":"not found"}`)
+	}
+}
+
+func (z *zoneServer) recordsOfType(t string) []DomainRecord {
+	z.Lock()
+	defer z.Unlock()
+
+	recs := make([]DomainRecord, 0)
+	for _, rec := range z.records {
+		if rec.Type == t {
+			recs = append(recs, *rec)
+		}
+	}
+	return recs
+}
+
+// newTestClient builds a client for the stand-in server without rate limiting
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Client{
+		baseURL: srv.URL,
+		client:  srv.Client(),
+	}
+}
+
+func TestReplaceDomainRecordsRollback(t *testing.T) {
+	zone := &zoneServer{
+		records: []*DomainRecord{
+			{Type: AType, Name: "@", Data: "127.0.0.1", TTL: 600},
+			{Type: CNameType, Name: "www", Data: "@", TTL: 3600},
+			{Type: MXType, Name: "@", Data: "mx.example.com", TTL: 3600, Priority: 10},
+		},
+		fail: map[string]bool{MXType: true},
+	}
+	client := newTestClient(t, zone)
+
+	before := map[string][]DomainRecord{}
+	for rt := range supportedTypes {
+		before[rt] = zone.recordsOfType(rt)
+	}
+
+	err := client.ReplaceDomainRecords(context.Background(), "", "example.com", []*DomainRecord{
+		{Type: AType, Name: "@", Data: "127.0.0.2", TTL: 600},
+		{Type: MXType, Name: "@", Data: "mx.example.net", TTL: 3600},
+	})
+
+	var replaceErr *ReplaceError
+	if !errors.As(err, &replaceErr) {
+		t.Fatalf("expected a ReplaceError, got %v", err)
+	}
+
+	assert.Equal(t, []string{MXType}, replaceErr.Failed)
+	assert.Equal(t, []string{NSType, AType, AAAAType, CNameType}, replaceErr.Changed)
+	assert.Equal(t, []string{CNameType, AAAAType, AType, NSType}, replaceErr.RolledBack)
+	assert.Contains(t, err.Error(), "MX records rejected")
+	for rt, recs := range before {
+		assert.Equal(t, recs, zone.recordsOfType(rt), "%s records were not restored", rt)
+	}
+}
+
+func TestReplaceDomainRecordsRollbackFailure(t *testing.T) {
+	zone := &zoneServer{
+		records: []*DomainRecord{
+			{Type: AType, Name: "@", Data: "127.0.0.1", TTL: 600},
+		},
+	}
+
+	// every type fails once the first write went through, including its rollback
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		zone.Lock()
+		if req.Method == http.MethodPut && len(zone.writes) > 0 {
+			zone.fail = map[string]bool{}
+			for rt := range supportedTypes {
+				zone.fail[rt] = true
+			}
+		}
+		zone.Unlock()
+		zone.ServeHTTP(w, req)
+	})
+	client := newTestClient(t, handler)
+
+	err := client.ReplaceDomainRecords(context.Background(), "", "example.com", []*DomainRecord{
+		{Type: AType, Name: "@", Data: "127.0.0.2", TTL: 600},
+	})
+
+	var replaceErr *ReplaceError
+	if !errors.As(err, &replaceErr) {
+		t.Fatalf("expected a ReplaceError, got %v", err)
+	}
+
+	assert.Len(t, replaceErr.Changed, 1)
+	assert.Empty(t, replaceErr.RolledBack)
+	assert.Len(t, replaceErr.Failed, 2)
+	assert.Equal(t, replaceErr.Changed[0], replaceErr.Failed[1])
+}
+
+func TestWriteOrderCoversRecordTypes(t *testing.T) {
+	for name, order := range map[string][]RecordType{"add": addOrder, "replace": replaceOrder} {
+		seen := make(map[RecordType]int)
+		for _, rt := range order {
+			seen[rt]++
+		}
+		for rt := A; rt <= TXT; rt++ {
+			assert.Equal(t, 1, seen[rt], "%s order should contain %s exactly once", name, rt)
+		}
+	}
+}
+
+func TestAddDomainRecordsOrder(t *testing.T) {
+	zone := &zoneServer{}
+	client := newTestClient(t, zone)
+
+	records := make([]*DomainRecord, 0)
+	for rt := TXT; rt >= A; rt-- {
+		records = append(records, &DomainRecord{Type: rt.String(), Name: "@", Data: "data", TTL: 600})
+	}
+
+	err := client.AddDomainRecords(context.Background(), "", "example.com", records)
+	assert.Nil(t, err)
+
+	// CAA records are never written
+	assert.Equal(t, []string{
+		"PATCH NS", "PATCH SOA", "PATCH A", "PATCH AAAA", "PATCH CNAME", "PATCH MX", "PATCH SRV", "PATCH TXT",
+	}, zone.writes)
+}
+
+func TestReplaceDomainRecordsOrder(t *testing.T) {
+	zone := &zoneServer{}
+	client := newTestClient(t, zone)
+
+	records := make([]*DomainRecord, 0)
+	for rt := TXT; rt >= A; rt-- {
+		records = append(records, &DomainRecord{Type: rt.String(), Name: "@", Data: "data", TTL: 600})
+	}
+
+	err := client.ReplaceDomainRecords(context.Background(), "", "example.com", records)
+	assert.Nil(t, err)
+
+	// CAA records are never written
+	assert.Equal(t, []string{
+		"PUT NS", "PUT SOA", "PUT A", "PUT AAAA", "PUT CNAME", "PUT MX", "PUT SRV", "PUT TXT",
+	}, zone.writes)
+}
+
+// countingHandler counts the GET requests served by the delegate
+type countingHandler struct {
+	delegate http.Handler
+	mu       sync.Mutex
+	gets     int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method == http.MethodGet {
+		h.mu.Lock()
+		h.gets++
+		h.mu.Unlock()
+	}
+	h.delegate.ServeHTTP(w, req)
+}
+
+func (h *countingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.gets
+}
+
+func TestGetDomainRecordsCache(t *testing.T) {
+	zone := &zoneServer{
+		records: []*DomainRecord{
+			{Type: AType, Name: "@", Data: "127.0.0.1", TTL: 600},
+		},
+	}
+	handler := &countingHandler{delegate: zone}
+	client := newTestClient(t, handler)
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	first, err := client.GetDomainRecords(ctx, "", "example.com")
+	assert.Nil(t, err)
+	fetched := handler.count()
+
+	// cached reads are copies and cost no request
+	first[0].Data = "changed"
+	second, err := client.GetDomainRecords(ctx, "", "EXAMPLE.com")
+	assert.Nil(t, err)
+	assert.Equal(t, fetched, handler.count())
+	assert.Equal(t, "127.0.0.1", second[0].Data)
+
+	// other customers don't share the cached reads
+	_, err = client.GetDomainRecords(ctx, "1234", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, 2*fetched, handler.count())
+
+	// writes drop the cached reads of the domain
+	err = client.AddDomainRecords(ctx, "", "example.com", []*DomainRecord{
+		{Type: TXTType, Name: "@", Data: "txt", TTL: 600},
+	})
+	assert.Nil(t, err)
+	third, err := client.GetDomainRecords(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, 3*fetched, handler.count())
+	assert.Len(t, third, 2)
+}
+
+func TestGetDomainRecordsWithoutCache(t *testing.T) {
+	handler := &countingHandler{delegate: &zoneServer{}}
+	client := newTestClient(t, handler)
+
+	_, err := client.GetDomainRecords(context.Background(), "", "example.com")
+	assert.Nil(t, err)
+	fetched := handler.count()
+
+	_, err = client.GetDomainRecords(context.Background(), "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, 2*fetched, handler.count())
+}
+
+func TestGetDomainRecordsPagination(t *testing.T) {
+	cases := map[string]struct {
+		records int
+		reads   int
+	}{
+		"Given an empty zone":                    {records: 0, reads: 1},
+		"Given a single record":                  {records: 1, reads: 1},
+		"Given exactly one full page of records": {records: 500, reads: 2},
+		"Given records spanning several pages":   {records: 1200, reads: 3},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			zone := &zoneServer{records: make([]*DomainRecord, 0, tc.records)}
+			for i := 0; i < tc.records; i++ {
+				zone.records = append(zone.records, &DomainRecord{Type: TXTType, Name: strconv.Itoa(i), Data: "data", TTL: 600})
+			}
+			client := newTestClient(t, zone)
+
+			records, err := client.GetDomainRecords(context.Background(), "", "example.com")
+			assert.Nil(t, err)
+			assert.Len(t, records, tc.records)
+			for i, rec := range records {
+				assert.Equal(t, strconv.Itoa(i), rec.Name)
+			}
+			assert.Equal(t, tc.reads, zone.reads)
+		})
+	}
+}
+
+func TestGetDomainRecordsPageGuard(t *testing.T) {
+	// a server ignoring the offset keeps returning the same full page
+	page := make([]*DomainRecord, defaultLimit)
+	for i := range page {
+		page[i] = &DomainRecord{Type: TXTType, Name: "@", Data: "data", TTL: 600}
+	}
+	reads := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reads++
+		w.Header().Set(headerContent, mediaTypeJSON)
+		json.NewEncoder(w).Encode(page)
+	}))
+
+	_, err := client.GetDomainRecords(context.Background(), "", "example.com")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, reads)
+}
+
+func TestGetDomainRecordsPageLimit(t *testing.T) {
+	// a server paging forever through distinct records
+	reads := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reads++
+		page := make([]*DomainRecord, defaultLimit)
+		for i := range page {
+			page[i] = &DomainRecord{Type: TXTType, Name: fmt.Sprintf("%d-%d", reads, i), Data: "data", TTL: 600}
+		}
+		w.Header().Set(headerContent, mediaTypeJSON)
+		json.NewEncoder(w).Encode(page)
+	}))
+
+	_, err := client.GetDomainRecords(context.Background(), "", "example.com")
+	assert.NotNil(t, err)
+	assert.Equal(t, maxRecordPages, reads)
+}
+
+// pendingServer serves a domain that stays pending for the first reads
+type pendingServer struct {
+	sync.Mutex
+	pending int
+	reads   int
+}
+
+func (p *pendingServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.reads++
+	status := StatusActive
+	if p.reads <= p.pending {
+		status = "PENDING_DNS_ACTIVE"
+	}
+
+	w.Header().Set(headerContent, mediaTypeJSON)
+	fmt.Fprintf(w, `{"domainId":1,"domain":"example.com","status":"%s"}`, status)
+}
+
+func TestGetDomainPending(t *testing.T) {
+	server := &pendingServer{pending: 2}
+	client := newTestClient(t, server)
+	client.cache = newResponseCache()
+
+	// pending domains are returned right away and never cached
+	for i := 0; i < 2; i++ {
+		d, err := client.GetDomain(context.Background(), "", "example.com")
+		assert.Nil(t, err)
+		assert.True(t, d.IsPending())
+	}
+	assert.Equal(t, 2, server.reads)
+}
+
+func TestWaitForDomain(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	cases := map[string]struct {
+		pending   int
+		timeout   time.Duration
+		expectErr bool
+		reads     int
+	}{
+		"Given an active domain":                       {pending: 0, timeout: time.Second, reads: 1},
+		"Given a domain pending for a few polls":       {pending: 4, timeout: time.Second, reads: 5},
+		"Given a domain pending longer than a timeout": {pending: 100, timeout: 10 * time.Millisecond, expectErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := &pendingServer{pending: tc.pending}
+			client := newTestClient(t, server)
+
+			d, err := client.WaitForDomain(context.Background(), "", "example.com", tc.timeout)
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "PENDING_DNS_ACTIVE")
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, StatusActive, d.Status)
+			assert.Equal(t, tc.reads, server.reads)
+		})
+	}
+}
+
+func TestWaitForDomainCanceled(t *testing.T) {
+	client := newTestClient(t, &pendingServer{pending: 100})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.WaitForDomain(ctx, "", "example.com", time.Hour)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestUpdateDomain(t *testing.T) {
+	locked, renew := true, false
+
+	cases := map[string]struct {
+		update   func(c *Client) error
+		expected string
+	}{
+		"Given new nameservers": {
+			update: func(c *Client) error {
+				return c.UpdateNSDomain(context.Background(), []string{"ns1.example.net"}, "", "example.com")
+			},
+			expected: `{"nameServers":["ns1.example.net"]}`,
+		},
+		"Given some of the settings": {
+			update: func(c *Client) error {
+				return c.UpdateDomain(context.Background(), "", "example.com", &DomainUpdate{Locked: &locked, RenewAuto: &renew})
+			},
+			expected: `{"locked":true,"renewAuto":false}`,
+		},
+		"Given an unlock": {
+			update: func(c *Client) error {
+				return c.LockDomain(context.Background(), "", "example.com", false)
+			},
+			expected: `{"locked":false}`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method, path, body string
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				b, _ := io.ReadAll(req.Body)
+				method, path, body = req.Method, req.URL.Path, string(b)
+			}))
+
+			assert.Nil(t, tc.update(client))
+			assert.Equal(t, http.MethodPatch, method)
+			assert.Equal(t, "/v1/domains/example.com", path)
+			assert.JSONEq(t, tc.expected, body)
+		})
+	}
+}
+
+func TestGetAuthCode(t *testing.T) {
+	authCode := "Xy7#kQ2p"
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set(headerContent, mediaTypeJSON)
+		fmt.Fprintf(w, `{"domainId":1,"domain":"example.com","status":"ACTIVE","locked":false,"authCode":%q}`, authCode)
+	}))
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	// a cached domain does not hide a regenerated auth code
+	_, err := client.GetDomain(ctx, "", "example.com")
+	assert.Nil(t, err)
+	authCode = "Lm3@vR8t"
+
+	code, err := client.GetAuthCode(ctx, "", "example.com")
+	assert.Nil(t, err)
+	assert.Equal(t, "Lm3@vR8t", code)
+
+	authCode = ""
+	_, err = client.GetAuthCode(ctx, "", "example.com")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	pathDomainForward = "%s/v2/customers/%s/domains/forwards/%s"
+
+	ForwardMasked    = "MASKED"
+	ForwardPermanent = "REDIRECT_PERMANENT"
+	ForwardTemporary = "REDIRECT_TEMPORARY"
+)
+
+// ForwardTypes lists the kinds of forwarding GoDaddy supports: a masked
+// forward keeps the domain in the address bar, a redirect answers 301 or 302
+var ForwardTypes = []string{ForwardMasked, ForwardPermanent, ForwardTemporary}
+
+// Forwarding sends the visitors of a domain, or of one of its subdomains, to
+// another URL
+type Forwarding struct {
+	FQDN string          `json:"fqdn,omitempty"`
+	Type string          `json:"type"`
+	URL  string          `json:"url"`
+	Mask *ForwardingMask `json:"mask,omitempty"`
+}
+
+// ForwardingMask holds the metadata of the page framing a masked forward
+type ForwardingMask struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Keywords    string `json:"keywords,omitempty"`
+}
+
+// ForwardingFQDN is the name forwarded for the subdomain of the domain, the
+// domain itself if the subdomain is empty or @
+func ForwardingFQDN(domain, subdomain string) string {
+	if subdomain == "" || subdomain == Ptr {
+		return domain
+	}
+	return subdomain + "." + domain
+}
+
+// Validate checks the forwarding before it is sent to GoDaddy
+func (f *Forwarding) Validate() error {
+	if !containsString(ForwardTypes, f.Type) {
+		return fmt.Errorf("invalid forwarding type %q, expected one of %s", f.Type, strings.Join(ForwardTypes, ", "))
+	}
+
+	u, err := url.Parse(f.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid forwarding url %q, expected an http or https URL", f.URL)
+	}
+
+	if f.Mask != nil && f.Type != ForwardMasked {
+		return fmt.Errorf("a mask only applies to %s forwarding, not to %s", ForwardMasked, f.Type)
+	}
+	return nil
+}
+
+// GetForwarding fetches the forwarding of the subdomain of the domain
+func (c *Client) GetForwarding(ctx context.Context, customerID, domain, subdomain string) (*Forwarding, error) {
+	ctx = c.logContext(ctx, domain)
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	fqdn := ForwardingFQDN(domain, subdomain)
+	forwardURL := fmt.Sprintf(pathDomainForward, c.baseURL, v2CustomerID, fqdn)
+	req, err := http.NewRequest(http.MethodGet, forwardURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var body json.RawMessage
+	if err := c.execute(ctx, customerID, req, &body); err != nil {
+		return nil, err
+	}
+
+	// the forwarding may come alone or along with the ones of the subdomains
+	forwards := make([]Forwarding, 0)
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+		err = json.Unmarshal(body, &forwards)
+	} else {
+		forwards = append(forwards, Forwarding{})
+		err = json.Unmarshal(body, &forwards[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range forwards {
+		if forwards[i].FQDN == "" || strings.EqualFold(forwards[i].FQDN, fqdn) {
+			return &forwards[i], nil
+		}
+	}
+
+	return nil, &APIError{StatusCode: http.StatusNotFound, Code: "NOT_FOUND", Message: fmt.Sprintf("no forwarding found for %s", fqdn)}
+}
+
+// CreateForwarding forwards the subdomain of the domain
+func (c *Client) CreateForwarding(ctx context.Context, customerID, domain, subdomain string, forwarding *Forwarding) error {
+	ctx = c.logContext(ctx, domain)
+	return c.writeForwarding(ctx, http.MethodPost, customerID, domain, subdomain, forwarding)
+}
+
+// UpdateForwarding replaces the forwarding of the subdomain of the domain
+func (c *Client) UpdateForwarding(ctx context.Context, customerID, domain, subdomain string, forwarding *Forwarding) error {
+	ctx = c.logContext(ctx, domain)
+	return c.writeForwarding(ctx, http.MethodPut, customerID, domain, subdomain, forwarding)
+}
+
+// DeleteForwarding stops forwarding the subdomain of the domain
+func (c *Client) DeleteForwarding(ctx context.Context, customerID, domain, subdomain string) error {
+	ctx = c.logContext(ctx, domain)
+	return c.writeForwarding(ctx, http.MethodDelete, customerID, domain, subdomain, nil)
+}
+
+// writeForwarding sends the forwarding, if any. GoDaddy points the records of
+// a forwarded name to its forwarding servers, so the cached records are dropped.
+func (c *Client) writeForwarding(ctx context.Context, method, customerID, domain, subdomain string, forwarding *Forwarding) error {
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	var body *bytes.Buffer
+	if forwarding != nil {
+		if err := forwarding.Validate(); err != nil {
+			return err
+		}
+
+		// the name is part of the path
+		msg, err := json.Marshal(&Forwarding{Type: forwarding.Type, URL: forwarding.URL, Mask: forwarding.Mask})
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(msg)
+	} else {
+		body = bytes.NewBuffer(nil)
+	}
+
+	v2CustomerID, err := c.GetCustomerID(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	forwardURL := fmt.Sprintf(pathDomainForward, c.baseURL, v2CustomerID, ForwardingFQDN(domain, subdomain))
+	req, err := http.NewRequest(method, forwardURL, body)
+
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, customerID, req, nil)
+}
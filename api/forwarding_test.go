@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForwardingFQDN(t *testing.T) {
+	assert.Equal(t, "example.com", ForwardingFQDN("example.com", ""))
+	assert.Equal(t, "example.com", ForwardingFQDN("example.com", "@"))
+	assert.Equal(t, "www.example.com", ForwardingFQDN("example.com", "www"))
+}
+
+func TestForwardingValidate(t *testing.T) {
+	cases := map[string]struct {
+		forwarding Forwarding
+		valid      bool
+	}{
+		"Given a redirect":           {Forwarding{Type: ForwardPermanent, URL: "https://example.net/landing"}, true},
+		"Given a masked forward":     {Forwarding{Type: ForwardMasked, URL: "http://example.net", Mask: &ForwardingMask{Title: "Example"}}, true},
+		"Given an unknown type":      {Forwarding{Type: "REDIRECT", URL: "https://example.net"}, false},
+		"Given a URL without scheme": {Forwarding{Type: ForwardTemporary, URL: "example.net"}, false},
+		"Given an ftp URL":           {Forwarding{Type: ForwardTemporary, URL: "ftp://example.net"}, false},
+		"Given a masked redirect":    {Forwarding{Type: ForwardPermanent, URL: "https://example.net", Mask: &ForwardingMask{Title: "Example"}}, false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.valid {
+				assert.Nil(t, tc.forwarding.Validate())
+			} else {
+				assert.NotNil(t, tc.forwarding.Validate())
+			}
+		})
+	}
+}
+
+func TestForwarding(t *testing.T) {
+	var requests, bodies []string
+	response := `{"fqdn":"www.example.com","type":"MASKED","url":"https://example.net","mask":{"title":"Example"}}`
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		bodies = append(bodies, string(body))
+		w.Header().Set(headerContent, mediaTypeJSON)
+
+		switch {
+		case req.URL.Path == "/v1/shoppers/467624111":
+			fmt.Fprint(w, `{"customerId":"01804e6f"}`)
+		case req.Method == http.MethodGet:
+			fmt.Fprint(w, response)
+		}
+	}))
+	client.customerID = "467624111"
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	forwarding := &Forwarding{Type: ForwardMasked, URL: "https://example.net", Mask: &ForwardingMask{Title: "Example"}}
+	assert.Nil(t, client.CreateForwarding(ctx, "", "example.com", "www", forwarding))
+	assert.Equal(t, "POST /v2/customers/01804e6f/domains/forwards/www.example.com", requests[1])
+	assert.JSONEq(t, `{"type":"MASKED","url":"https://example.net","mask":{"title":"Example"}}`, bodies[1])
+
+	actual, err := client.GetForwarding(ctx, "", "example.com", "www")
+	assert.Nil(t, err)
+	assert.Equal(t, &Forwarding{FQDN: "www.example.com", Type: ForwardMasked, URL: "https://example.net", Mask: &ForwardingMask{Title: "Example"}}, actual)
+
+	// the forwarding may be listed along with the ones of the subdomains
+	response = `[{"fqdn":"shop.example.com","type":"REDIRECT_TEMPORARY","url":"https://shop.example.net"},{"fqdn":"example.com","type":"REDIRECT_PERMANENT","url":"https://example.net"}]`
+	actual, err = client.GetForwarding(ctx, "", "example.com", "")
+	assert.Nil(t, err)
+	assert.Equal(t, ForwardPermanent, actual.Type)
+
+	_, err = client.GetForwarding(ctx, "", "example.com", "blog")
+	assert.True(t, IsNotFound(err))
+
+	forwarding.Type, forwarding.Mask = ForwardTemporary, nil
+	assert.Nil(t, client.UpdateForwarding(ctx, "", "example.com", "www", forwarding))
+	assert.Equal(t, "PUT /v2/customers/01804e6f/domains/forwards/www.example.com", requests[len(requests)-1])
+
+	assert.Nil(t, client.DeleteForwarding(ctx, "", "example.com", "www"))
+	assert.Equal(t, "DELETE /v2/customers/01804e6f/domains/forwards/www.example.com", requests[len(requests)-1])
+}
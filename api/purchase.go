@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	pathAgreements       = "%s/v1/domains/agreements?tlds=%s&privacy=%t&forTransfer=%t"
+	pathPurchase         = "%s/v1/domains/purchase"
+	pathPurchaseValidate = "%s/v1/domains/purchase/validate"
+	pathDomainRenew      = "%s/v1/domains/%s/renew"
+)
+
+// Agreement is a legal agreement that has to be accepted to register or
+// transfer a domain
+type Agreement struct {
+	Key     string `json:"agreementKey"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Content string `json:"content,omitempty"`
+}
+
+// Consent records who accepted which agreements and when
+type Consent struct {
+	AgreementKeys []string `json:"agreementKeys"`
+	// AgreedBy is the IP address of the person who accepted the agreements
+	AgreedBy string `json:"agreedBy"`
+	// AgreedAt is formatted as RFC 3339
+	AgreedAt string `json:"agreedAt"`
+}
+
+// NewConsent accepts the agreements on behalf of the provided IP address
+func NewConsent(agreementKeys []string, agreedBy string, agreedAt time.Time) Consent {
+	return Consent{
+		AgreementKeys: agreementKeys,
+		AgreedBy:      agreedBy,
+		AgreedAt:      agreedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// DomainPurchase is the order to register a domain
+type DomainPurchase struct {
+	Domain      string   `json:"domain"`
+	Consent     Consent  `json:"consent"`
+	Period      int      `json:"period,omitempty"`
+	NameServers []string `json:"nameServers,omitempty"`
+	Privacy     bool     `json:"privacy"`
+	RenewAuto   bool     `json:"renewAuto"`
+	DomainContacts
+}
+
+// PurchaseOrder is the order placed for a domain purchase. The total is
+// expressed in micro-units of the currency.
+type PurchaseOrder struct {
+	OrderID   int64  `json:"orderId"`
+	ItemCount int    `json:"itemCount"`
+	Total     int64  `json:"total"`
+	Currency  string `json:"currency"`
+}
+
+// TLD returns the top level domain of the domain, e.g. co.uk for example.co.uk
+func TLD(domain string) string {
+	if i := strings.Index(domain, "."); i >= 0 {
+		return domain[i+1:]
+	}
+	return domain
+}
+
+// GetAgreements fetches the agreements to accept for registering domains of
+// the provided top level domains, or for transferring them in
+func (c *Client) GetAgreements(ctx context.Context, tlds []string, privacy, forTransfer bool) ([]Agreement, error) {
+	ctx = c.logContext(ctx, "")
+
+	agreementsURL := fmt.Sprintf(pathAgreements, c.baseURL, url.QueryEscape(strings.Join(tlds, ",")), privacy, forTransfer)
+	req, err := http.NewRequest(http.MethodGet, agreementsURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	agreements := make([]Agreement, 0)
+	if err := c.execute(ctx, "", req, &agreements); err != nil {
+		return nil, err
+	}
+
+	return agreements, nil
+}
+
+// MissingAgreements lists the agreements the consent does not accept
+func MissingAgreements(agreements []Agreement, consent Consent) []Agreement {
+	accepted := make(map[string]struct{}, len(consent.AgreementKeys))
+	for _, key := range consent.AgreementKeys {
+		accepted[key] = struct{}{}
+	}
+
+	missing := make([]Agreement, 0)
+	for _, agreement := range agreements {
+		if _, ok := accepted[agreement.Key]; !ok {
+			missing = append(missing, agreement)
+		}
+	}
+	return missing
+}
+
+// ValidatePurchase checks whether the purchase would be accepted, without
+// placing an order
+func (c *Client) ValidatePurchase(ctx context.Context, customerID string, purchase *DomainPurchase) error {
+	ctx = c.logContext(ctx, purchase.Domain)
+	return c.postPurchase(ctx, customerID, pathPurchaseValidate, purchase, nil)
+}
+
+// PurchaseDomain places the order to register the domain. The domain is
+// registered asynchronously, see WaitForRegistration.
+func (c *Client) PurchaseDomain(ctx context.Context, customerID string, purchase *DomainPurchase) (*PurchaseOrder, error) {
+	ctx = c.logContext(ctx, purchase.Domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, purchase.Domain))
+
+	order := new(PurchaseOrder)
+	if err := c.postPurchase(ctx, customerID, pathPurchase, purchase, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (c *Client) postPurchase(ctx context.Context, customerID, path string, purchase *DomainPurchase, result interface{}) error {
+	if purchase.Registrant != nil {
+		if err := purchase.DomainContacts.Validate(); err != nil {
+			return err
+		}
+	}
+
+	msg, err := json.Marshal(purchase)
+	if err != nil {
+		return err
+	}
+
+	buffer := bytes.NewBuffer(msg)
+
+	purchaseURL := fmt.Sprintf(path, c.baseURL)
+	req, err := http.NewRequest(http.MethodPost, purchaseURL, buffer)
+
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, customerID, req, result)
+}
+
+// WaitForRegistration fetches the details of a purchased domain once it has
+// been registered, polling it until the timeout expires
+func (c *Client) WaitForRegistration(ctx context.Context, customerID, domain string, timeout time.Duration) (*Domain, error) {
+	ctx = c.logContext(ctx, domain)
+	return c.pollDomain(ctx, customerID, domain, timeout, true)
+}
+
+// RenewDomain extends the registration of the domain by the period in years
+func (c *Client) RenewDomain(ctx context.Context, customerID, domain string, period int) (*PurchaseOrder, error) {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	msg, err := json.Marshal(struct {
+		Period int `json:"period"`
+	}{period})
+	if err != nil {
+		return nil, err
+	}
+
+	domainURL := fmt.Sprintf(pathDomainRenew, c.baseURL, domain)
+	req, err := http.NewRequest(http.MethodPost, domainURL, bytes.NewBuffer(msg))
+
+	if err != nil {
+		return nil, err
+	}
+
+	order := new(PurchaseOrder)
+	if err := c.execute(ctx, customerID, req, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CancelDomain cancels the registration of the domain
+func (c *Client) CancelDomain(ctx context.Context, customerID, domain string) error {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	domainURL := fmt.Sprintf(pathDomains, c.baseURL, domain)
+	req, err := http.NewRequest(http.MethodDelete, domainURL, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return c.execute(ctx, customerID, req, nil)
+}
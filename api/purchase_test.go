@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLD(t *testing.T) {
+	assert.Equal(t, "com", TLD("example.com"))
+	assert.Equal(t, "co.uk", TLD("example.co.uk"))
+	assert.Equal(t, "localhost", TLD("localhost"))
+}
+
+func TestMissingAgreements(t *testing.T) {
+	agreements := []Agreement{{Key: "DNRA"}, {Key: "DNPA"}}
+
+	assert.Empty(t, MissingAgreements(agreements, Consent{AgreementKeys: []string{"DNPA", "DNRA"}}))
+	assert.Equal(t, []Agreement{{Key: "DNPA"}}, MissingAgreements(agreements, Consent{AgreementKeys: []string{"DNRA"}}))
+}
+
+func TestNewConsent(t *testing.T) {
+	at := time.Date(2023, 11, 6, 14, 49, 42, 0, time.FixedZone("MSK", 3*60*60))
+	consent := NewConsent([]string{"DNRA"}, "192.0.2.1", at)
+	assert.Equal(t, "2023-11-06T11:49:42Z", consent.AgreedAt)
+}
+
+// registrarServer is a stand-in for the purchase API that registers a domain
+// a few reads after it was purchased
+type registrarServer struct {
+	sync.Mutex
+	requests []string
+	reads    int
+	purchase DomainPurchase
+}
+
+func (r *registrarServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.requests = append(r.requests, req.Method+" "+req.URL.Path)
+	w.Header().Set(headerContent, mediaTypeJSON)
+
+	switch {
+	case req.URL.Path == "/v1/domains/agreements":
+		fmt.Fprint(w, `[{"agreementKey":"DNRA","title":"Domain Name Registration Agreement","url":"https://example.com/dnra"}]`)
+	case req.URL.Path == "/v1/domains/purchase/validate":
+		w.WriteHeader(http.StatusNoContent)
+	case req.URL.Path == "/v1/domains/purchase":
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &r.purchase)
+		fmt.Fprint(w, `{"orderId":42,"itemCount":1,"total":11990000,"currency":"USD"}`)
+	case req.Method == http.MethodGet:
+		r.reads++
+		switch {
+		case r.reads == 1:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"NOT_FOUND","message":"domain not found"}`)
+		case r.reads == 2:
+			fmt.Fprint(w, `{"domainId":1,"domain":"example.com","status":"PENDING_SETUP"}`)
+		default:
+			fmt.Fprint(w, `{"domainId":1,"domain":"example.com","status":"ACTIVE"}`)
+		}
+	case req.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestPurchaseDomain(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	registrar := &registrarServer{}
+	client := newTestClient(t, registrar)
+	ctx := context.Background()
+
+	agreements, err := client.GetAgreements(ctx, []string{"com"}, false, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "DNRA", agreements[0].Key)
+
+	purchase := &DomainPurchase{
+		Domain:         "example.com",
+		Consent:        NewConsent([]string{"DNRA"}, "192.0.2.1", time.Now()),
+		Period:         1,
+		RenewAuto:      true,
+		DomainContacts: DomainContacts{Registrant: testContact()},
+	}
+	assert.Nil(t, client.ValidatePurchase(ctx, "", purchase))
+
+	order, err := client.PurchaseDomain(ctx, "", purchase)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), order.OrderID)
+	assert.Equal(t, []string{"DNRA"}, registrar.purchase.Consent.AgreementKeys)
+	assert.Equal(t, testContact(), registrar.purchase.Registrant)
+
+	// the domain is not found, then pending, then registered
+	domain, err := client.WaitForRegistration(ctx, "", "example.com", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, StatusActive, domain.Status)
+	assert.Equal(t, 3, registrar.reads)
+
+	assert.Nil(t, client.CancelDomain(ctx, "", "example.com"))
+	assert.Equal(t, "DELETE /v1/domains/example.com", registrar.requests[len(registrar.requests)-1])
+}
+
+func TestPurchaseDomainInvalidContacts(t *testing.T) {
+	registrar := &registrarServer{}
+	client := newTestClient(t, registrar)
+
+	registrant := testContact()
+	registrant.Phone = "480-505-8800"
+	_, err := client.PurchaseDomain(context.Background(), "", &DomainPurchase{
+		Domain:         "example.com",
+		DomainContacts: DomainContacts{Registrant: registrant},
+	})
+
+	assert.NotNil(t, err)
+	assert.Empty(t, registrar.requests)
+}
+
+func TestRenewDomain(t *testing.T) {
+	var path, body string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		path, body = req.Method+" "+req.URL.Path, string(b)
+		w.Header().Set(headerContent, mediaTypeJSON)
+		fmt.Fprint(w, `{"orderId":43,"itemCount":1,"total":23980000,"currency":"USD"}`)
+	}))
+
+	order, err := client.RenewDomain(context.Background(), "", "example.com", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(43), order.OrderID)
+	assert.Equal(t, "POST /v1/domains/example.com/renew", path)
+	assert.JSONEq(t, `{"period":2}`, body)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	pathSuggest = "%s/v1/domains/suggest?%s"
+	pathTLDs    = "%s/v1/domains/tlds"
+)
+
+// SuggestOptions narrows the domains suggested for a query. Zero values are
+// left to the API defaults.
+type SuggestOptions struct {
+	Query     string
+	Country   string
+	City      string
+	Sources   []string
+	TLDs      []string
+	LengthMin int
+	LengthMax int
+	Limit     int
+}
+
+// DomainSuggestion is a domain suggested for a query
+type DomainSuggestion struct {
+	Domain string `json:"domain"`
+}
+
+// TopLevelDomain is a top level domain for sale, its type is either GENERIC
+// or COUNTRY_CODE
+type TopLevelDomain struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (o *SuggestOptions) values() url.Values {
+	v := url.Values{}
+	v.Set("query", o.Query)
+
+	for key, value := range map[string]string{
+		"country": o.Country,
+		"city":    o.City,
+		"sources": strings.Join(o.Sources, ","),
+		"tlds":    strings.Join(o.TLDs, ","),
+	} {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	for key, value := range map[string]int{
+		"lengthMin": o.LengthMin,
+		"lengthMax": o.LengthMax,
+		"limit":     o.Limit,
+	} {
+		if value > 0 {
+			v.Set(key, strconv.Itoa(value))
+		}
+	}
+
+	return v
+}
+
+// SuggestDomains fetches domains suggested for the query. Suggestions may
+// be tailored to the provided customer.
+func (c *Client) SuggestDomains(ctx context.Context, customerID string, opts *SuggestOptions) ([]DomainSuggestion, error) {
+	ctx = c.logContext(ctx, "")
+
+	if strings.TrimSpace(opts.Query) == "" {
+		return nil, fmt.Errorf("a query is required to suggest domains")
+	}
+
+	suggestURL := fmt.Sprintf(pathSuggest, c.baseURL, opts.values().Encode())
+	req, err := http.NewRequest(http.MethodGet, suggestURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]DomainSuggestion, 0)
+	if err := c.execute(ctx, customerID, req, &suggestions); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
+// GetTLDs fetches the top level domains that can be registered
+func (c *Client) GetTLDs(ctx context.Context) ([]TopLevelDomain, error) {
+	ctx = c.logContext(ctx, "")
+
+	tldsURL := fmt.Sprintf(pathTLDs, c.baseURL)
+	req, err := http.NewRequest(http.MethodGet, tldsURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlds := make([]TopLevelDomain, 0)
+	if err := c.execute(ctx, "", req, &tlds); err != nil {
+		return nil, err
+	}
+
+	return tlds, nil
+}
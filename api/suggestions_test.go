@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestDomains(t *testing.T) {
+	var query, customer string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query, customer = req.URL.RawQuery, req.Header.Get(headerCustomerID)
+		w.Header().Set(headerContent, mediaTypeJSON)
+		fmt.Fprint(w, `[{"domain":"example-shop.com"},{"domain":"example-store.net"}]`)
+	}))
+
+	cases := map[string]struct {
+		opts     *SuggestOptions
+		expected string
+	}{
+		"Given only a query": {
+			opts:     &SuggestOptions{Query: "example shop"},
+			expected: "query=example+shop",
+		},
+		"Given every option": {
+			opts: &SuggestOptions{
+				Query: "example", Country: "US", City: "Scottsdale", Sources: []string{"KEYWORD_SPIN", "CC_TLD"},
+				TLDs: []string{"com", "net"}, LengthMin: 5, LengthMax: 20, Limit: 10,
+			},
+			expected: "city=Scottsdale&country=US&lengthMax=20&lengthMin=5&limit=10&query=example&sources=KEYWORD_SPIN%2CCC_TLD&tlds=com%2Cnet",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			suggestions, err := client.SuggestDomains(context.Background(), "1234", tc.opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, query)
+			assert.Equal(t, "1234", customer)
+			assert.Equal(t, []DomainSuggestion{{Domain: "example-shop.com"}, {Domain: "example-store.net"}}, suggestions)
+		})
+	}
+
+	_, err := client.SuggestDomains(context.Background(), "", &SuggestOptions{Query: " "})
+	assert.NotNil(t, err)
+}
+
+func TestGetTLDs(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/v1/domains/tlds", req.URL.Path)
+		w.Header().Set(headerContent, mediaTypeJSON)
+		fmt.Fprint(w, `[{"name":"com","type":"GENERIC"},{"name":"de","type":"COUNTRY_CODE"}]`)
+	}))
+
+	tlds, err := client.GetTLDs(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []TopLevelDomain{{Name: "com", Type: "GENERIC"}, {Name: "de", Type: "COUNTRY_CODE"}}, tlds)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	pathDomainTransfer = "%s/v1/domains/%s/transfer"
+)
+
+// DomainTransfer is the order to transfer a domain from another registrar
+type DomainTransfer struct {
+	// AuthCode is issued by the losing registrar to authorize the transfer
+	AuthCode  string  `json:"authCode"`
+	Consent   Consent `json:"consent"`
+	Period    int     `json:"period,omitempty"`
+	Privacy   bool    `json:"privacy"`
+	RenewAuto bool    `json:"renewAuto"`
+}
+
+// TransferDomain places the order to transfer the domain into the account.
+// The transfer is processed asynchronously, see WaitForTransfer.
+func (c *Client) TransferDomain(ctx context.Context, customerID, domain string, transfer *DomainTransfer) (*PurchaseOrder, error) {
+	ctx = c.logContext(ctx, domain)
+	defer c.cache.invalidate(c.cacheKey(customerID, domain))
+
+	if strings.TrimSpace(transfer.AuthCode) == "" {
+		return nil, fmt.Errorf("an auth code is required to transfer %s", domain)
+	}
+
+	msg, err := json.Marshal(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	buffer := bytes.NewBuffer(msg)
+
+	transferURL := fmt.Sprintf(pathDomainTransfer, c.baseURL, domain)
+	req, err := http.NewRequest(http.MethodPost, transferURL, buffer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	order := new(PurchaseOrder)
+	if err := c.execute(ctx, customerID, req, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// WaitForTransfer polls the transfer of the domain ordered after the provided
+// time of the last transfer, see LastActionTime, until it completes or the
+// timeout expires. A transfer that failed or was cancelled is returned along
+// with an *ActionError.
+func (c *Client) WaitForTransfer(ctx context.Context, customerID, domain string, after time.Time, timeout time.Duration) (*Action, error) {
+	return c.WaitForAction(ctx, customerID, domain, ActionTransfer, after, timeout)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// transferServer is a stand-in for the transfer and v2 actions APIs that
+// walks the transfer through the provided statuses, one per read
+type transferServer struct {
+	sync.Mutex
+	requests []string
+	shoppers int
+	statuses []string
+	reason   string
+	transfer DomainTransfer
+}
+
+func (s *transferServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.requests = append(s.requests, req.Method+" "+req.URL.Path)
+	w.Header().Set(headerContent, mediaTypeJSON)
+
+	switch req.URL.Path {
+	case "/v1/shoppers/467624111":
+		s.shoppers++
+		fmt.Fprint(w, `{"shopperId":"467624111","customerId":"01804e6f-6562-4304-be20-6563ee6d7573"}`)
+	case "/v1/domains/example.com/transfer":
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &s.transfer)
+		fmt.Fprint(w, `{"orderId":44,"itemCount":1,"total":9990000,"currency":"USD"}`)
+	case "/v2/customers/01804e6f-6562-4304-be20-6563ee6d7573/domains/example.com/actions/DOMAIN_TRANSFER":
+		status := s.statuses[0]
+		if len(s.statuses) > 1 {
+			s.statuses = s.statuses[1:]
+		}
+		if status == "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"NOT_FOUND","message":"action not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"type":"DOMAIN_TRANSFER","status":%q%s}`, status, s.reason)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestTransferDomain(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	server := &transferServer{statuses: []string{"", ActionPending, ActionAwaiting, ActionSuccess}}
+	client := newTestClient(t, server)
+	client.customerID = "467624111"
+	client.cache = newResponseCache()
+	ctx := context.Background()
+
+	order, err := client.TransferDomain(ctx, "", "example.com", &DomainTransfer{
+		AuthCode:  "Xy7#kQ2p",
+		Consent:   NewConsent([]string{"DNTA"}, "192.0.2.1", time.Now()),
+		Period:    1,
+		RenewAuto: true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(44), order.OrderID)
+	assert.Equal(t, "Xy7#kQ2p", server.transfer.AuthCode)
+
+	// the action is not found, then pending, awaiting and finally successful
+	action, err := client.WaitForTransfer(ctx, "", "example.com", time.Time{}, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, ActionSuccess, action.Status)
+
+	// the customer ID is resolved once
+	action, err = client.GetDomainAction(ctx, "", "example.com", ActionTransfer)
+	assert.Nil(t, err)
+	assert.Equal(t, ActionSuccess, action.Status)
+	assert.Equal(t, 1, server.shoppers)
+}
+
+func TestTransferDomainRejected(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	server := &transferServer{
+		statuses: []string{ActionPending, ActionFailed},
+		reason:   `,"reason":{"code":"TRANSFER_REJECTED","message":"The losing registrar rejected the transfer"}`,
+	}
+	client := newTestClient(t, server)
+
+	action, err := client.WaitForTransfer(context.Background(), "467624111", "example.com", time.Time{}, time.Second)
+
+	var actionErr *ActionError
+	assert.True(t, errors.As(err, &actionErr))
+	assert.Equal(t, ActionFailed, action.Status)
+	assert.Equal(t, "DOMAIN_TRANSFER of example.com ended with status FAILED: The losing registrar rejected the transfer (TRANSFER_REJECTED)", err.Error())
+}
+
+func TestWaitForTransferTimeout(t *testing.T) {
+	defer func(min, max time.Duration) { pendingDelayMin, pendingDelayMax = min, max }(pendingDelayMin, pendingDelayMax)
+	pendingDelayMin, pendingDelayMax = time.Millisecond, 4*time.Millisecond
+
+	server := &transferServer{statuses: []string{ActionAwaiting}}
+	client := newTestClient(t, server)
+
+	action, err := client.WaitForTransfer(context.Background(), "467624111", "example.com", time.Time{}, 20*time.Millisecond)
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, ActionAwaiting, action.Status)
+}
+
+func TestGetCustomerIDWithoutCustomer(t *testing.T) {
+	server := &transferServer{}
+	client := newTestClient(t, server)
+
+	_, err := client.GetCustomerID(context.Background(), "")
+	assert.NotNil(t, err)
+	assert.Empty(t, server.requests)
+}
+
+func TestTransferDomainWithoutAuthCode(t *testing.T) {
+	server := &transferServer{}
+	client := newTestClient(t, server)
+
+	_, err := client.TransferDomain(context.Background(), "", "example.com", &DomainTransfer{})
+	assert.NotNil(t, err)
+	assert.Empty(t, server.requests)
+}
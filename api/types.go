@@ -3,7 +3,9 @@ package api
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 )
 
 // RecordType is an enumeration of possible DNS record types
@@ -66,6 +68,9 @@ const (
 	StatusActive    = "ACTIVE"
 	StatusCancelled = "CANCELLED"
 
+	// ParkedData is the data GoDaddy assigns to the A record of a parked domain
+	ParkedData = "Parked"
+
 	Ptr       = "@"
 	AType     = "A"
 	AAAAType  = "AAAA"
@@ -78,6 +83,24 @@ const (
 	TXTType   = "TXT"
 )
 
+// DefaultNameServers are the GoDaddy hosted nameservers a domain falls back
+// to when custom nameservers are no longer in use
+var DefaultNameServers = []string{
+	"ns1.domaincontrol.com",
+	"ns2.domaincontrol.com",
+}
+
+// addOrder is the order in which AddDomainRecords writes record types.
+// Nameservers and the start of authority go first so that new nameservers are
+// in place before any other record is added, followed by the address records
+// that aliases and mail exchangers may point at.
+var addOrder = []RecordType{NS, SOA, A, AAAA, CNAME, MX, SRV, TXT, CAA}
+
+// replaceOrder is the order in which ReplaceDomainRecords writes record types.
+// Nameservers and the start of authority are replaced first, the remaining
+// types follow the RecordType enumeration. Rollbacks run in reverse.
+var replaceOrder = []RecordType{NS, SOA, A, AAAA, CAA, CNAME, MX, SRV, TXT}
+
 var supportedTypes = map[string]struct{}{
 	AType:     {},
 	AAAAType:  {},
@@ -96,6 +119,36 @@ type Domain struct {
 	Name        string   `json:"domain"`
 	Status      string   `json:"status"`
 	NameServers []string `json:"nameServers"`
+	Locked      bool     `json:"locked"`
+	RenewAuto   bool     `json:"renewAuto"`
+	ExposeWhois bool     `json:"exposeWhois"`
+	// AuthCode authorizes a transfer of the domain to another registrar
+	AuthCode string `json:"authCode"`
+	DomainContacts
+	Verifications Verifications `json:"verifications"`
+	Expires       time.Time     `json:"expires"`
+	// RenewDeadline is the last moment the domain can be renewed after it expired
+	RenewDeadline time.Time `json:"renewDeadline"`
+}
+
+// DomainUpdate holds the settings of a domain to change. Settings left nil
+// are not sent and remain as they are.
+type DomainUpdate struct {
+	NameServers []string `json:"nameServers,omitempty"`
+	Locked      *bool    `json:"locked,omitempty"`
+	RenewAuto   *bool    `json:"renewAuto,omitempty"`
+	ExposeWhois *bool    `json:"exposeWhois,omitempty"`
+}
+
+// DaysUntilExpiry is the number of whole days left before the domain expires,
+// negative once it expired
+func (d *Domain) DaysUntilExpiry(now time.Time) int {
+	return int(math.Floor(d.Expires.Sub(now).Hours() / 24))
+}
+
+// IsPending reports whether GoDaddy is still processing a change to the domain
+func (d *Domain) IsPending() bool {
+	return strings.Contains(d.Status, "PENDING")
 }
 
 // DomainRecord encapsulates a domain record resource
@@ -111,6 +164,27 @@ type DomainRecord struct {
 	Port     *int   `json:"port,omitempty"`
 }
 
+// ReplaceError reports a partially applied record replacement. Changed lists
+// the record types written before the failure, RolledBack the ones restored
+// afterwards and Failed the type that could not be written followed by any
+// type that could not be restored.
+type ReplaceError struct {
+	Domain     string
+	Changed    []string
+	RolledBack []string
+	Failed     []string
+	Err        error
+}
+
+func (e *ReplaceError) Error() string {
+	return fmt.Sprintf("couldn't replace %s records: %s (changed: [%s], rolled back: [%s], failed: [%s])",
+		e.Domain, e.Err, strings.Join(e.Changed, ", "), strings.Join(e.RolledBack, ", "), strings.Join(e.Failed, ", "))
+}
+
+func (e *ReplaceError) Unwrap() error {
+	return e.Err
+}
+
 // DomainRecordOpt provides support for setting optional parameters
 type DomainRecordOpt func(*DomainRecord) error
 
@@ -137,8 +211,8 @@ func NewDomainRecord(name, t, data string, ttl int, opts ...DomainRecordOpt) (*D
 	}
 	if !IsSupportedType(t) {
 		var types []string
-		for t := range supportedTypes {
-			types = append(types, t)
+		for rt := A; rt <= TXT; rt++ {
+			types = append(types, rt.String())
 		}
 		return nil, fmt.Errorf("type must be one of: %s", types)
 	}
@@ -263,6 +337,11 @@ func IsDefaultARecord(record *DomainRecord) bool {
 	return record.Name == Ptr && record.Type == AType && record.TTL == DefaultTTL
 }
 
+// IsParkedRecord is a predicate to identify the A records GoDaddy creates for its parked page
+func IsParkedRecord(record *DomainRecord) bool {
+	return record.Type == AType && strings.EqualFold(record.Data, ParkedData)
+}
+
 // IsDefaultNSRecord is a predicate to place fetched NS domain records into the appropriate bucket
 // func IsDefaultNSRecord(record *DomainRecord) bool {
 // 	return record.Name == Ptr && record.Type == NSType && record.TTL == DefaultTTL
@@ -280,3 +359,80 @@ func IsSupportedType(recType string) bool {
 	}
 	return false
 }
+
+// ValidateRecordSet checks a complete record set for conflicts that GoDaddy
+// would otherwise only reject while the records are being written: CNAME
+// records at the apex or next to other data (RFC 1034, section 3.6.2),
+// duplicate records, misplaced SOA records and NS delegations that occlude
+// other records. Records without a name or data are skipped as their values
+// may not be known yet.
+func ValidateRecordSet(records []*DomainRecord) error {
+	var problems []string
+
+	byName := make(map[string][]*DomainRecord)
+	names := make([]string, 0)
+	seen := make(map[string]struct{})
+	soaCount := 0
+
+	for _, rec := range records {
+		if rec == nil || rec.Name == "" || rec.Data == "" {
+			continue
+		}
+
+		name := strings.ToLower(rec.Name)
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+		byName[name] = append(byName[name], rec)
+
+		key := strings.Join([]string{
+			strings.ToUpper(rec.Type), name, rec.Data, rec.Service, rec.Protocol,
+		}, "|")
+		if _, ok := seen[key]; ok {
+			problems = append(problems, fmt.Sprintf("duplicate %s record %s -> %s", rec.Type, rec.Name, rec.Data))
+		}
+		seen[key] = struct{}{}
+
+		switch {
+		case strings.EqualFold(rec.Type, CNameType) && rec.Name == Ptr:
+			problems = append(problems, "CNAME records are not allowed at the zone apex (@)")
+		case strings.EqualFold(rec.Type, SOAType):
+			soaCount++
+			if rec.Name != Ptr {
+				problems = append(problems, fmt.Sprintf("SOA record %s must be defined at the zone apex (@)", rec.Name))
+			}
+		}
+	}
+
+	if soaCount > 1 {
+		problems = append(problems, "only one SOA record is allowed per zone")
+	}
+
+	for _, name := range names {
+		recs := byName[name]
+		var cname, ns, other int
+		for _, rec := range recs {
+			switch strings.ToUpper(rec.Type) {
+			case CNameType:
+				cname++
+			case NSType:
+				ns++
+			default:
+				other++
+			}
+		}
+
+		if cname > 0 && len(recs) > 1 {
+			problems = append(problems, fmt.Sprintf("CNAME record %s cannot coexist with other records of the same name", recs[0].Name))
+		}
+		if ns > 0 && name != Ptr && other > 0 {
+			problems = append(problems, fmt.Sprintf("NS records delegate %s, other records of the same name would be ignored", recs[0].Name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid record set: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 )
 
 func TestNewDomainRecord(t *testing.T) {
@@ -35,3 +37,93 @@ func randBinaryString(n int) string {
 	}
 	return string(out)
 }
+
+func TestValidateRecordSet(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		Records  []*DomainRecord
+		Negative bool
+	}{
+		{"Given a valid record set", []*DomainRecord{
+			{Name: "@", Type: AType, Data: "127.0.0.1"},
+			{Name: "@", Type: MXType, Data: "mx.example.com"},
+			{Name: "www", Type: CNameType, Data: "@"},
+			{Name: "sub", Type: NSType, Data: "ns1.example.net"},
+			{Name: "sub", Type: NSType, Data: "ns2.example.net"},
+		}, false},
+		{"Given a CNAME at the apex", []*DomainRecord{
+			{Name: "@", Type: CNameType, Data: "example.net"},
+		}, true},
+		{"Given a CNAME next to other records", []*DomainRecord{
+			{Name: "www", Type: CNameType, Data: "@"},
+			{Name: "www", Type: TXTType, Data: "hello"},
+		}, true},
+		{"Given two CNAMEs of the same name", []*DomainRecord{
+			{Name: "www", Type: CNameType, Data: "a.example.net"},
+			{Name: "WWW", Type: CNameType, Data: "b.example.net"},
+		}, true},
+		{"Given duplicate records", []*DomainRecord{
+			{Name: "@", Type: AType, Data: "127.0.0.1", TTL: 600},
+			{Name: "@", Type: AType, Data: "127.0.0.1", TTL: 3600},
+		}, true},
+		{"Given an SOA record outside the apex", []*DomainRecord{
+			{Name: "www", Type: SOAType, Data: "ns1.example.net"},
+		}, true},
+		{"Given multiple SOA records", []*DomainRecord{
+			{Name: "@", Type: SOAType, Data: "ns1.example.net"},
+			{Name: "@", Type: SOAType, Data: "ns2.example.net"},
+		}, true},
+		{"Given a delegation next to other records", []*DomainRecord{
+			{Name: "sub", Type: NSType, Data: "ns1.example.net"},
+			{Name: "sub", Type: AType, Data: "127.0.0.1"},
+		}, true},
+		{"Given records with unknown values", []*DomainRecord{
+			{Name: "@", Type: CNameType, Data: ""},
+			{Name: "", Type: AType, Data: "127.0.0.1"},
+		}, false},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			err := ValidateRecordSet(test.Records)
+			if err != nil && !test.Negative {
+				t.Errorf("unexpected validation error: %s", err)
+			}
+			if err == nil && test.Negative {
+				t.Errorf("expected record set to be rejected")
+			}
+		})
+	}
+}
+
+func TestDaysUntilExpiry(t *testing.T) {
+	now := time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC)
+
+	var criteria = []struct {
+		Name     string
+		Expires  time.Time
+		Expected int
+	}{
+		{"Given a domain expiring in a month", now.AddDate(0, 1, 0), 30},
+		{"Given a domain expiring later today", now.Add(6 * time.Hour), 0},
+		{"Given a domain that expired yesterday", now.Add(-20 * time.Hour), -1},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			d := &Domain{Expires: test.Expires}
+			if days := d.DaysUntilExpiry(now); days != test.Expected {
+				t.Errorf("expected %d days until expiry, got %d", test.Expected, days)
+			}
+		})
+	}
+}
+
+func TestDomainExpiryJSON(t *testing.T) {
+	d := new(Domain)
+	err := json.Unmarshal([]byte(`{"domain":"example.com","expires":"2024-11-06T11:49:42.000Z","renewDeadline":"2024-12-21T11:49:42.000Z"}`), d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Expires.Year() != 2024 || d.RenewDeadline.Month() != time.December {
+		t.Errorf("unexpected expiry %s, renew deadline %s", d.Expires, d.RenewDeadline)
+	}
+}
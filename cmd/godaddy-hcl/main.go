@@ -0,0 +1,92 @@
+// Command godaddy-hcl generates godaddy_domain_record resources and import
+// blocks for domains that already exist in a GoDaddy account.
+//
+//	godaddy-hcl [-customer id] [-profile name] [-credentials file] [-environment production|ote] [-baseurl url] [-out file] [domain ...]
+//
+// The API key pair is resolved like the provider does: GODADDY_API_KEY and
+// GODADDY_API_SECRET take precedence over the profile of the shared
+// credentials file. When no domains are provided, every active domain of the
+// account is generated.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/b13f/terraform-provider-godaddy/plugin/godaddy"
+)
+
+// version is set by the build for released binaries
+var version = "dev"
+
+func main() {
+	customer := flag.String("customer", "", "customer id owning the domains")
+	profile := flag.String("profile", os.Getenv("GODADDY_PROFILE"), "profile of the shared credentials file")
+	credentials := flag.String("credentials", envDefault("GODADDY_SHARED_CREDENTIALS_FILE", godaddy.DefaultCredentialsFile), "path to the shared credentials file")
+	environment := flag.String("environment", os.Getenv("GODADDY_ENVIRONMENT"), "GoDaddy environment, production or ote")
+	baseURL := flag.String("baseurl", "", "GoDaddy base url, overrides the url of the environment")
+	out := flag.String("out", "", "file to write the configuration to (defaults to stdout)")
+	verbose := flag.Bool("v", false, "log api requests to stderr")
+	flag.Parse()
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	config := godaddy.Config{
+		Key:         os.Getenv("GODADDY_API_KEY"),
+		Secret:      os.Getenv("GODADDY_API_SECRET"),
+		Customer:    os.Getenv("GODADDY_API_CUSTOMER_ID"),
+		Environment: *environment,
+		BaseURL:     *baseURL,
+		UserAgent:   "godaddy-hcl/" + version,
+	}
+	if *customer != "" {
+		config.Customer = *customer
+	}
+
+	if err := run(&config, *profile, *credentials, *customer, *out, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(config *godaddy.Config, profile, credentials, customer, out string, domains []string) error {
+	if err := config.LoadProfile(credentials, profile); err != nil {
+		return err
+	}
+
+	if config.Key == "" || config.Secret == "" {
+		return fmt.Errorf("GODADDY_API_KEY and GODADDY_API_SECRET or a shared credentials profile must be set")
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return godaddy.GenerateConfig(context.Background(), w, client, customer, domains)
+}
+
+// envDefault returns the value of the environment variable, or the fallback
+// if it is not set
+func envDefault(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
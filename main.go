@@ -20,6 +20,6 @@ var (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: godaddy.Provider,
+		ProviderFunc: godaddy.New(version),
 	})
 }
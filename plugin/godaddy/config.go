@@ -1,23 +1,73 @@
 package godaddy
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 // Config provides the provider's configuration
 type Config struct {
-	Key    string
-	Secret string
-	//	CustomerID string
-	BaseURL string
+	Key            string
+	Secret         string
+	Customer       string
+	Environment    string
+	RateLimit      int
+	BaseURL        string
+	ProxyURL       string
+	CABundle       string
+	RequestTimeout time.Duration
+	UserAgent      string
 }
 
 // Client returns a new client for accessing GoDaddy.
 func (c *Config) Client() (*api.Client, error) {
-	client, err := api.NewClient(c.BaseURL, c.Key, c.Secret)
+	opts := []api.ClientOpt{api.WithUserAgent(c.UserAgent), api.WithCache()}
+
+	if c.ProxyURL != "" {
+		opts = append(opts, api.WithProxy(c.ProxyURL))
+	}
+
+	if c.CABundle != "" {
+		pem, err := os.ReadFile(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle: %s", err)
+		}
+		opts = append(opts, api.WithRootCAs(pem))
+	}
+
+	if c.RequestTimeout > 0 {
+		opts = append(opts, api.WithTimeout(c.RequestTimeout))
+	}
+
+	if c.Customer != "" {
+		opts = append(opts, api.WithCustomerID(c.Customer))
+	}
+
+	if c.RateLimit > 0 {
+		opts = append(opts, api.WithRateLimit(c.RateLimit))
+	}
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		var ok bool
+		if baseURL, ok = api.Environments[c.Environment]; !ok {
+			return nil, fmt.Errorf("error setting up client: unknown environment %q", c.Environment)
+		}
+	}
+
+	client, err := api.NewClient(baseURL, c.Key, c.Secret, opts...)
 
 	if err != nil {
 		return nil, fmt.Errorf("error setting up client: %s", err)
@@ -27,3 +77,145 @@ func (c *Config) Client() (*api.Client, error) {
 
 	return client, nil
 }
+
+// LoadProfile fills the settings that were neither configured nor set in
+// the environment from the shared credentials profile, then applies defaults.
+// The key and secret are taken from the profile together or not at all, so
+// they never belong to different accounts. A named profile must exist, while
+// the default one used when no name is provided may be missing.
+func (c *Config) LoadProfile(path, name string) error {
+	required := true
+	if name == "" {
+		name, required = DefaultProfile, false
+	}
+
+	profile, err := loadProfile(path, name, required)
+	if err != nil {
+		return err
+	}
+
+	if c.Key == "" && c.Secret == "" {
+		c.Key, c.Secret = profile.Key, profile.Secret
+	}
+	if c.Customer == "" {
+		c.Customer = profile.Customer
+	}
+	if c.Environment == "" {
+		c.Environment = profile.Environment
+	}
+	if c.RateLimit == 0 {
+		c.RateLimit = profile.RateLimit
+	}
+
+	if c.Environment == "" {
+		c.Environment = DefaultEnvironment
+	}
+
+	return nil
+}
+
+// validatedCredentials caches the definite outcomes of credential validation
+// for the lifetime of the plugin process, keyed by credentialsKey
+var validatedCredentials sync.Map
+
+// credentialsKey identifies the credentials and endpoint of a configuration
+// without keeping the secret itself in memory
+func (c *Config) credentialsKey() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{c.Environment, c.BaseURL, c.Customer, c.Key, c.Secret}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateCredentials makes a single authenticated request to verify the
+// credentials, distinguishing invalid keys, keys of another environment,
+// missing access and accounts without domains. The result is cached unless
+// the validation failed for another reason, such as a server or network
+// error, which the next attempt may not run into.
+func (c *Config) ValidateCredentials(ctx context.Context, client *api.Client) diag.Diagnostics {
+	key := c.credentialsKey()
+	if cached, ok := validatedCredentials.Load(key); ok {
+		return cached.(diag.Diagnostics)
+	}
+
+	diags, definite := c.validateCredentials(ctx, client)
+	if definite {
+		validatedCredentials.Store(key, diags)
+	}
+	return diags
+}
+
+// validateCredentials also reports whether the outcome is definite, that is
+// the credentials were accepted or rejected by GoDaddy
+func (c *Config) validateCredentials(ctx context.Context, client *api.Client) (diag.Diagnostics, bool) {
+	var diags diag.Diagnostics
+
+	log.Println("Validating GoDaddy credentials...")
+	hasDomains, err := client.CheckCredentials(ctx, "")
+
+	var apiErr *api.APIError
+	switch {
+	case err == nil && !hasDomains:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "No GoDaddy domains found",
+			Detail:   "The API credentials are valid, but no domain is registered under the account that owns the key.",
+		}), true
+	case err == nil:
+		return diags, true
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized:
+		if other := c.otherEnvironment(); other != "" && c.validForEnvironment(ctx, other) {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "GoDaddy API key issued for another environment",
+				Detail: fmt.Sprintf("The API key is valid for the %s environment, but the provider is configured for %s. "+
+					"Set environment = %q or use a key issued for %s.", other, c.Environment, other, c.Environment),
+			}), true
+		}
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid GoDaddy API credentials",
+			Detail:   fmt.Sprintf("The API key and secret were rejected: %s", err),
+		}), true
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "GoDaddy API access denied",
+			Detail:   fmt.Sprintf("The API credentials are valid, but are not allowed to access the domains API: %s", err),
+		}), true
+	default:
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to validate GoDaddy API credentials",
+			Detail: fmt.Sprintf("%s\n\nSet skip_credentials_validation = true to configure the provider without "+
+				"validating the credentials.", err),
+		}), false
+	}
+}
+
+// otherEnvironment names the environment the key might have been issued for
+// instead, unless the base url has been overridden
+func (c *Config) otherEnvironment() string {
+	if c.BaseURL != "" {
+		return ""
+	}
+
+	switch c.Environment {
+	case api.EnvProduction:
+		return api.EnvOTE
+	case api.EnvOTE:
+		return api.EnvProduction
+	}
+	return ""
+}
+
+func (c *Config) validForEnvironment(ctx context.Context, env string) bool {
+	other := *c
+	other.Environment = env
+
+	client, err := other.Client()
+	if err != nil {
+		return false
+	}
+
+	_, err = client.CheckCredentials(ctx, "")
+	return err == nil
+}
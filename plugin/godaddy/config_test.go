@@ -0,0 +1,92 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		Status   int
+		Body     string
+		Severity diag.Severity
+		Summary  string
+		Calls    int32
+	}{
+		{"Given valid credentials", http.StatusOK, `[{"domain":"example.com"}]`, -1, "", 1},
+		{"Given an account without domains", http.StatusOK, `[]`, diag.Warning, "No GoDaddy domains found", 1},
+		{"Given an invalid key", http.StatusUnauthorized, `{"code":"UNABLE_TO_AUTHENTICATE","message":"Unable to authenticate request"}`,
+			diag.Error, "Invalid GoDaddy API credentials", 1},
+		{"Given a key without access", http.StatusForbidden, `{"code":"ACCESS_DENIED","message":"Authenticated user is not allowed access"}`,
+			diag.Error, "GoDaddy API access denied", 1},
+		{"Given a server error", http.StatusInternalServerError, `{"code":"INTERNAL_SERVER_ERROR","message":"oops"}`,
+			diag.Error, "Unable to validate GoDaddy API credentials", 2},
+	}
+	for i, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(test.Status)
+				fmt.Fprint(w, test.Body)
+			}))
+			defer srv.Close()
+
+			config := &Config{
+				Key:         fmt.Sprintf("key-%d", i),
+				Secret:      "secret",
+				Environment: api.EnvProduction,
+				BaseURL:     srv.URL,
+			}
+			client, err := config.Client()
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			diags := config.ValidateCredentials(context.Background(), client)
+			if test.Summary == "" {
+				assert.Empty(t, diags)
+			} else if assert.Len(t, diags, 1) {
+				assert.Equal(t, test.Severity, diags[0].Severity)
+				assert.Equal(t, test.Summary, diags[0].Summary)
+			}
+
+			// a definite outcome is cached for the same credentials, a transient failure is retried
+			assert.Equal(t, diags, config.ValidateCredentials(context.Background(), client))
+			assert.Equal(t, test.Calls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestValidateCredentialsNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	srv.Close()
+
+	config := &Config{Key: "key-unreachable", Secret: "secret", Environment: api.EnvProduction, BaseURL: srv.URL}
+	client, err := config.Client()
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	diags := config.ValidateCredentials(context.Background(), client)
+	assert.True(t, diags.HasError())
+
+	// the failure is not cached
+	_, cached := validatedCredentials.Load(config.credentialsKey())
+	assert.False(t, cached)
+}
+
+func TestOtherEnvironment(t *testing.T) {
+	assert.Equal(t, api.EnvOTE, (&Config{Environment: api.EnvProduction}).otherEnvironment())
+	assert.Equal(t, api.EnvProduction, (&Config{Environment: api.EnvOTE}).otherEnvironment())
+	assert.Equal(t, "", (&Config{Environment: api.EnvOTE, BaseURL: "https://godaddy.internal"}).otherEnvironment())
+}
@@ -0,0 +1,150 @@
+package godaddy
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultCredentialsFile is the shared credentials file read by the provider
+	DefaultCredentialsFile = "~/.godaddy/credentials"
+	// DefaultProfile is the profile used unless configured otherwise
+	DefaultProfile = "default"
+)
+
+// credentialsProfile is a named set of settings in the shared credentials
+// file. It can be written as an INI section or as a JSON object:
+//
+//	[default]
+//	key         = abc
+//	secret      = 123
+//	environment = ote
+//	customer    = 1234
+//	rate_limit  = 60
+//
+//	{"default": {"key": "abc", "secret": "123", "environment": "ote", "customer": "1234", "rate_limit": 60}}
+type credentialsProfile struct {
+	Key         string `json:"key"`
+	Secret      string `json:"secret"`
+	Environment string `json:"environment"`
+	Customer    string `json:"customer"`
+	RateLimit   int    `json:"rate_limit"`
+}
+
+// loadProfile reads the named profile from the shared credentials file. A
+// missing file or profile is only an error if required is set.
+func loadProfile(path, name string, required bool) (*credentialsProfile, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !required {
+		return &credentialsProfile{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading shared credentials file: %s", err)
+	}
+
+	profiles, err := parseCredentials(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing shared credentials file %s: %s", path, err)
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("profile %q not found in shared credentials file %s", name, path)
+		}
+		return &credentialsProfile{}, nil
+	}
+
+	return profile, nil
+}
+
+// parseCredentials parses the profiles of a shared credentials file written
+// either as JSON or as INI
+func parseCredentials(data []byte) (map[string]*credentialsProfile, error) {
+	profiles := make(map[string]*credentialsProfile)
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		// unknown settings are rejected as they are in INI
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&profiles); err != nil {
+			return nil, err
+		}
+		if decoder.More() {
+			return nil, fmt.Errorf("unexpected data after the profiles")
+		}
+		return profiles, nil
+	}
+
+	var current *credentialsProfile
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", n)
+			}
+			current = &credentialsProfile{}
+			profiles[name] = current
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: setting outside of a [profile] section", n)
+		}
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "key":
+			current.Key = value
+		case "secret":
+			current.Secret = value
+		case "environment":
+			current.Environment = value
+		case "customer":
+			current.Customer = value
+		case "rate_limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: rate_limit must be a number", n)
+			}
+			current.RateLimit = limit
+		default:
+			return nil, fmt.Errorf("line %d: unknown setting %q", n, key)
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error resolving home directory: %s", err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
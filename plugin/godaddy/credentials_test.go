@@ -0,0 +1,123 @@
+package godaddy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const iniCredentials = `
+# shared GoDaddy credentials
+[default]
+key    = default-key
+secret = default-secret
+
+[reseller]
+key         = reseller-key
+secret      = reseller-secret
+environment = ote
+customer    = 1234
+rate_limit  = 30
+`
+
+const jsonCredentials = `{
+  "default": {"key": "default-key", "secret": "default-secret"},
+  "reseller": {"key": "reseller-key", "secret": "reseller-secret", "environment": "ote", "customer": "1234", "rate_limit": 30}
+}`
+
+func TestParseCredentials(t *testing.T) {
+	expected := map[string]*credentialsProfile{
+		"default": {Key: "default-key", Secret: "default-secret"},
+		"reseller": {
+			Key:         "reseller-key",
+			Secret:      "reseller-secret",
+			Environment: api.EnvOTE,
+			Customer:    "1234",
+			RateLimit:   30,
+		},
+	}
+
+	for name, data := range map[string]string{"INI": iniCredentials, "JSON": jsonCredentials} {
+		t.Run("Given "+name+" credentials", func(t *testing.T) {
+			profiles, err := parseCredentials([]byte(data))
+			assert.Nil(t, err)
+			assert.Equal(t, expected, profiles)
+		})
+	}
+
+	var criteria = []struct {
+		Name string
+		Data string
+	}{
+		{"Given a setting outside of a profile", "key = abc"},
+		{"Given an unknown setting", "[default]\nregion = us"},
+		{"Given a line without a value", "[default]\nkey"},
+		{"Given an invalid rate limit", "[default]\nrate_limit = fast"},
+		{"Given an empty profile name", "[ ]"},
+		{"Given invalid JSON", "{\"default\": "},
+		{"Given an unknown JSON setting", `{"default": {"key": "abc", "region": "us"}}`},
+		{"Given a JSON setting in the wrong case", `{"default": {"key": "abc", "rateLimit": 30}}`},
+		{"Given data after the JSON profiles", `{"default": {"key": "abc"}} {}`},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := parseCredentials([]byte(test.Data))
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestConfigLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	assert.Nil(t, os.WriteFile(path, []byte(iniCredentials), 0600))
+
+	t.Run("Given an empty configuration", func(t *testing.T) {
+		config := &Config{}
+		assert.Nil(t, config.LoadProfile(path, "reseller"))
+		assert.Equal(t, &Config{
+			Key:         "reseller-key",
+			Secret:      "reseller-secret",
+			Environment: api.EnvOTE,
+			Customer:    "1234",
+			RateLimit:   30,
+		}, config)
+	})
+
+	t.Run("Given configured settings", func(t *testing.T) {
+		config := &Config{Key: "hcl-key", Secret: "hcl-secret", Environment: api.EnvProduction, RateLimit: 10}
+		assert.Nil(t, config.LoadProfile(path, "reseller"))
+		assert.Equal(t, "hcl-key", config.Key)
+		assert.Equal(t, "hcl-secret", config.Secret)
+		assert.Equal(t, api.EnvProduction, config.Environment)
+		assert.Equal(t, "1234", config.Customer)
+		assert.Equal(t, 10, config.RateLimit)
+	})
+
+	t.Run("Given only a configured key", func(t *testing.T) {
+		// the secret of the profile belongs to another key
+		config := &Config{Key: "hcl-key"}
+		assert.Nil(t, config.LoadProfile(path, "reseller"))
+		assert.Equal(t, "hcl-key", config.Key)
+		assert.Empty(t, config.Secret)
+	})
+
+	t.Run("Given only a configured secret", func(t *testing.T) {
+		config := &Config{Secret: "hcl-secret"}
+		assert.Nil(t, config.LoadProfile(path, "reseller"))
+		assert.Empty(t, config.Key)
+		assert.Equal(t, "hcl-secret", config.Secret)
+	})
+
+	t.Run("Given a missing default profile", func(t *testing.T) {
+		config := &Config{}
+		assert.Nil(t, config.LoadProfile(filepath.Join(t.TempDir(), "missing"), ""))
+		assert.Equal(t, &Config{Environment: DefaultEnvironment}, config)
+	})
+
+	t.Run("Given a missing named profile", func(t *testing.T) {
+		assert.NotNil(t, (&Config{}).LoadProfile(path, "unknown"))
+	})
+}
@@ -0,0 +1,98 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDomain() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainRead,
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrStatus: {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			attrNameservers: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			attrLocked: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			attrRenewAuto: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			attrExposeWhois: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			attrExpires: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the domain expires, formatted as RFC 3339.",
+			},
+			attrRenewDeadline: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last moment the domain can be renewed after it expired, formatted as RFC 3339.",
+			},
+			attrDaysUntilExpiry: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of whole days left before the domain expires, negative once it expired.",
+			},
+		},
+	}
+}
+
+func dataSourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	customer := d.Get(zattrCustomer).(string)
+	name := d.Get(attrDomain).(string)
+
+	log.Println("Fetching", name, "info...")
+	domain, err := client.GetDomain(ctx, customer, name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", name, err.Error()))
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	for attr, value := range map[string]interface{}{
+		attrStatus:      domain.Status,
+		attrNameservers: domain.NameServers,
+		attrLocked:      domain.Locked,
+		attrRenewAuto:   domain.RenewAuto,
+		attrExposeWhois: domain.ExposeWhois,
+	} {
+		if err = d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err = setExpiry(d, domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
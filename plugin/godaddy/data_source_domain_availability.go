@@ -0,0 +1,170 @@
+package godaddy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrDomains    = "domains"
+	attrCheckType  = "check_type"
+	attrResults    = "results"
+	attrAvailable  = "available"
+	attrDefinitive = "definitive"
+	attrPrice      = "price"
+	attrCurrency   = "currency"
+	attrPeriod     = "period"
+)
+
+// availabilitySchema are the attributes describing the availability of a domain
+func availabilitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		attrAvailable: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the domain can be registered.",
+		},
+		attrDefinitive: {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the availability was confirmed with the registry rather than served from a cache.",
+		},
+		attrPrice: {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "Registration price for the period, in units of the currency.",
+		},
+		attrCurrency: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		attrPeriod: {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Registration period of the price in years.",
+		},
+	}
+}
+
+func dataSourceDomainAvailability() *schema.Resource {
+	s := availabilitySchema()
+
+	result := availabilitySchema()
+	result[attrDomain] = &schema.Schema{Type: schema.TypeString, Computed: true}
+
+	s[attrDomain] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{attrDomain, attrDomains},
+		Description:  "Domain to check. The availability attributes describe this domain.",
+	}
+	s[attrDomains] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MinItems:    1,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Domains to check at once. Their availability is listed in `results`.",
+	}
+	s[attrCheckType] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      api.CheckTypeFast,
+		ValidateFunc: validation.StringInSlice([]string{api.CheckTypeFast, api.CheckTypeFull}, false),
+		Description:  "`FAST` may serve the availability from a cache, `FULL` confirms it with the registry.",
+	}
+	s[attrResults] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Resource{Schema: result},
+		Description: "Availability of every checked domain.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDomainAvailabilityRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceDomainAvailabilityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	checkType := d.Get(attrCheckType).(string)
+
+	var diags diag.Diagnostics
+	var results []*api.DomainAvailability
+	if domain, ok := d.GetOk(attrDomain); ok {
+		log.Println("Checking", domain, "availability...")
+		availability, err := client.CheckDomainAvailability(ctx, domain.(string), checkType)
+		if err != nil {
+			return diag.Errorf("couldn't check availability (%s): %s", domain, err.Error())
+		}
+
+		for attr, value := range map[string]interface{}{
+			attrAvailable:  availability.Available,
+			attrDefinitive: availability.Definitive,
+			attrPrice:      availability.PriceAmount(),
+			attrCurrency:   availability.Currency,
+			attrPeriod:     availability.Period,
+		} {
+			if err = d.Set(attr, value); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.SetId(domain.(string))
+		results = []*api.DomainAvailability{availability}
+	} else {
+		domains := make([]string, 0)
+		for _, item := range d.Get(attrDomains).([]interface{}) {
+			domains = append(domains, item.(string))
+		}
+
+		log.Println("Checking availability of", len(domains), "domains...")
+		var err error
+		results, err = client.CheckDomainsAvailability(ctx, domains, checkType)
+
+		// the domains that could be checked are listed, the others are warned about
+		var availabilityErr *api.AvailabilityError
+		if errors.As(err, &availabilityErr) {
+			for _, failure := range availabilityErr.Failures {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Couldn't check availability of %s", failure.Domain),
+					Detail:   fmt.Sprintf("%s (%s). The domain is missing from the results.", failure.Message, failure.Code),
+				})
+			}
+		} else if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(strings.Join(domains, ","))
+	}
+
+	if err := d.Set(attrResults, flattenAvailability(results)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenAvailability(list []*api.DomainAvailability) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(list))
+	for i, a := range list {
+		result[i] = map[string]interface{}{
+			attrDomain:     a.Domain,
+			attrAvailable:  a.Available,
+			attrDefinitive: a.Definitive,
+			attrPrice:      a.PriceAmount(),
+			attrCurrency:   a.Currency,
+			attrPeriod:     a.Period,
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package godaddy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainAvailabilityPartialFailure(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var domains []string
+		body, _ := io.ReadAll(req.Body)
+		json.Unmarshal(body, &domains)
+
+		var results, failures []string
+		for _, domain := range domains {
+			if strings.HasSuffix(domain, ".invalid") {
+				failures = append(failures, fmt.Sprintf(`{"domain":%q,"code":"UNSUPPORTED_TLD","message":"TLD is not supported"}`, domain))
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"domain":%q,"available":true,"definitive":true,"price":11990000,"currency":"USD","period":1}`, domain))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"domains":[%s],"errors":[%s]}`, strings.Join(results, ","), strings.Join(failures, ","))
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceDomainAvailability().Schema, map[string]interface{}{
+		attrDomains: []interface{}{"example.com", "example.invalid", "example.net"},
+	})
+	diags := dataSourceDomainAvailabilityRead(context.Background(), d, client)
+
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "Couldn't check availability of example.invalid", diags[0].Summary)
+	}
+
+	assert.Equal(t, 2, d.Get(attrResults+".#"))
+	assert.Equal(t, "example.com", d.Get(attrResults+".0."+attrDomain))
+	assert.Equal(t, "example.net", d.Get(attrResults+".1."+attrDomain))
+	assert.Equal(t, 11.99, d.Get(attrResults+".1."+attrPrice))
+}
@@ -0,0 +1,157 @@
+package godaddy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrQuery     = "query"
+	attrCountry   = "country"
+	attrCity      = "city"
+	attrSources   = "sources"
+	attrTLDs      = "tlds"
+	attrLengthMin = "length_min"
+	attrLengthMax = "length_max"
+	attrLimit     = "limit"
+)
+
+func dataSourceDomainSuggestions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainSuggestionsRead,
+
+		Schema: map[string]*schema.Schema{
+			attrQuery: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Keywords or a domain to base the suggestions on.",
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Customer ID the suggestions are tailored to.",
+			},
+			attrCountry: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Two letter ISO country code to tailor country code TLDs to.",
+			},
+			attrCity: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "City to tailor geographic TLDs to.",
+			},
+			attrSources: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						"CC_TLD", "EXTENSION", "KEYWORD_SPIN", "PREMIUM",
+					}, false),
+				},
+				Description: "Sources of the suggestions: `CC_TLD`, `EXTENSION`, `KEYWORD_SPIN` or `PREMIUM`.",
+			},
+			attrTLDs: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Top level domains the suggestions are restricted to.",
+			},
+			attrLengthMin: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Minimum length of the second level domain.",
+			},
+			attrLengthMax: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum length of the second level domain.",
+			},
+			attrLimit: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of suggestions.",
+			},
+			attrDomains: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Suggested domains.",
+			},
+		},
+	}
+}
+
+func dataSourceDomainSuggestionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+
+	opts := &api.SuggestOptions{
+		Query:     d.Get(attrQuery).(string),
+		Country:   d.Get(attrCountry).(string),
+		City:      d.Get(attrCity).(string),
+		Sources:   stringList(d.Get(attrSources)),
+		TLDs:      stringList(d.Get(attrTLDs)),
+		LengthMin: d.Get(attrLengthMin).(int),
+		LengthMax: d.Get(attrLengthMax).(int),
+		Limit:     d.Get(attrLimit).(int),
+	}
+
+	log.Println("Fetching suggestions for", opts.Query, "...")
+	suggestions, err := client.SuggestDomains(ctx, d.Get(zattrCustomer).(string), opts)
+	if err != nil {
+		return diag.Errorf("couldn't suggest domains (%s): %s", opts.Query, err.Error())
+	}
+
+	domains := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		domains[i] = suggestion.Domain
+	}
+
+	if err = d.Set(attrDomains, domains); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(suggestionsID(d.Get(zattrCustomer).(string), opts))
+
+	return nil
+}
+
+// suggestionsID identifies the suggestions by a hash of all the inputs, as
+// any of them changes the suggested domains
+func suggestionsID(customer string, opts *api.SuggestOptions) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		customer,
+		opts.Query,
+		opts.Country,
+		opts.City,
+		strings.Join(opts.Sources, ","),
+		strings.Join(opts.TLDs, ","),
+		strconv.Itoa(opts.LengthMin),
+		strconv.Itoa(opts.LengthMax),
+		strconv.Itoa(opts.Limit),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+func stringList(v interface{}) []string {
+	list := make([]string, 0)
+	for _, item := range v.([]interface{}) {
+		list = append(list, item.(string))
+	}
+	return list
+}
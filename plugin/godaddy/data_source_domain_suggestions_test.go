@@ -0,0 +1,38 @@
+package godaddy
+
+import (
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestionsID(t *testing.T) {
+	base := func() *api.SuggestOptions {
+		return &api.SuggestOptions{Query: "example", TLDs: []string{"com", "net"}, Limit: 10}
+	}
+	id := suggestionsID("", base())
+	assert.Equal(t, id, suggestionsID("", base()))
+
+	cases := map[string]struct {
+		customer string
+		update   func(o *api.SuggestOptions)
+	}{
+		"Given another customer":       {"1234", func(o *api.SuggestOptions) {}},
+		"Given another country":        {"", func(o *api.SuggestOptions) { o.Country = "US" }},
+		"Given another city":           {"", func(o *api.SuggestOptions) { o.City = "Scottsdale" }},
+		"Given other sources":          {"", func(o *api.SuggestOptions) { o.Sources = []string{"KEYWORD_SPIN"} }},
+		"Given other tlds":             {"", func(o *api.SuggestOptions) { o.TLDs = []string{"com"} }},
+		"Given another minimum length": {"", func(o *api.SuggestOptions) { o.LengthMin = 5 }},
+		"Given another maximum length": {"", func(o *api.SuggestOptions) { o.LengthMax = 12 }},
+		"Given another limit":          {"", func(o *api.SuggestOptions) { o.Limit = 20 }},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			opts := base()
+			tc.update(opts)
+			assert.NotEqual(t, id, suggestionsID(tc.customer, opts))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package godaddy
+
+import (
+	"context"
+	"log"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrNames = "names"
+	attrType  = "type"
+)
+
+func dataSourceTLDs() *schema.Resource {
+	tld := map[string]*schema.Schema{
+		recName:  {Type: schema.TypeString, Computed: true},
+		attrType: {Type: schema.TypeString, Computed: true},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceTLDsRead,
+
+		Schema: map[string]*schema.Schema{
+			attrType: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GENERIC", "COUNTRY_CODE"}, false),
+				Description:  "Only list top level domains of this type: `GENERIC` or `COUNTRY_CODE`.",
+			},
+			attrTLDs: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Resource{Schema: tld},
+				Description: "Top level domains that can be registered.",
+			},
+			attrNames: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the top level domains, e.g. `com`.",
+			},
+		},
+	}
+}
+
+func dataSourceTLDsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	tldType := d.Get(attrType).(string)
+
+	log.Println("Fetching TLDs...")
+	tlds, err := client.GetTLDs(ctx)
+	if err != nil {
+		return diag.Errorf("couldn't list TLDs: %s", err.Error())
+	}
+
+	list := make([]map[string]interface{}, 0, len(tlds))
+	names := make([]string, 0, len(tlds))
+	for _, tld := range tlds {
+		if tldType != "" && tld.Type != tldType {
+			continue
+		}
+		list = append(list, map[string]interface{}{recName: tld.Name, attrType: tld.Type})
+		names = append(names, tld.Name)
+	}
+
+	if err = d.Set(attrTLDs, list); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set(attrNames, names); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if tldType == "" {
+		tldType = "ALL"
+	}
+	d.SetId(tldType)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const resourceDomainRecordType = "godaddy_domain_record"
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// GenerateConfig writes a godaddy_domain_record resource along with its
+// import block for each of the provided domains. If no domains are provided,
+// every active domain of the customer is generated.
+func GenerateConfig(ctx context.Context, w io.Writer, client *api.Client, customer string, domains []string) error {
+	if len(domains) == 0 {
+		log.Println("Fetching domains...")
+		list, err := client.GetDomains(ctx, customer)
+		if err != nil {
+			return fmt.Errorf("couldn't list domains: %s", err.Error())
+		}
+
+		for _, domain := range list {
+			if domain.Status == api.StatusActive {
+				domains = append(domains, domain.Name)
+			}
+		}
+	}
+
+	names := make(map[string]bool)
+	for _, domain := range domains {
+		log.Println("Fetching", domain, "records...")
+		records, err := client.GetDomainRecords(ctx, customer, domain)
+		if err != nil {
+			return fmt.Errorf("couldn't find domain record (%s): %s", domain, err.Error())
+		}
+
+		tracked, _ := stateRecords(records, false, parkedIgnore)
+		name := uniqueResourceName(resourceName(domain), names)
+		if _, err = w.Write(domainRecordConfig(customer, domain, name, tracked)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// domainRecordConfig renders the HCL for a single domain under the resource
+// name. Optional record attributes are only written when they differ from
+// their schema default so that the generated configuration plans cleanly
+// after import.
+func domainRecordConfig(customer, domain, name string, records []*api.DomainRecord) []byte {
+	sorted := make([]*api.DomainRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		switch {
+		case sorted[i].Type != sorted[j].Type:
+			return sorted[i].Type < sorted[j].Type
+		case sorted[i].Name != sorted[j].Name:
+			return sorted[i].Name < sorted[j].Name
+		default:
+			return sorted[i].Data < sorted[j].Data
+		}
+	})
+
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+
+	resource := root.AppendNewBlock("resource", []string{resourceDomainRecordType, name}).Body()
+	resource.SetAttributeValue(attrDomain, cty.StringVal(domain))
+	if customer != "" {
+		resource.SetAttributeValue(zattrCustomer, cty.StringVal(customer))
+	}
+
+	for _, rec := range sorted {
+		resource.AppendNewline()
+		record := resource.AppendNewBlock(attrRecord, nil).Body()
+		record.SetAttributeValue(recName, cty.StringVal(rec.Name))
+		record.SetAttributeValue(recType, cty.StringVal(rec.Type))
+		record.SetAttributeValue(recData, cty.StringVal(rec.Data))
+		record.SetAttributeValue(recTTL, cty.NumberIntVal(int64(rec.TTL)))
+		if rec.Priority != api.DefaultPriority {
+			record.SetAttributeValue(recPriority, cty.NumberIntVal(int64(rec.Priority)))
+		}
+		if rec.Weight != api.DefaultWeight {
+			record.SetAttributeValue(recWeight, cty.NumberIntVal(int64(rec.Weight)))
+		}
+		if rec.Service != "" {
+			record.SetAttributeValue(recService, cty.StringVal(rec.Service))
+		}
+		if rec.Protocol != "" {
+			record.SetAttributeValue(recProto, cty.StringVal(rec.Protocol))
+		}
+		if rec.Port != nil && *rec.Port != api.DefaultPort {
+			record.SetAttributeValue(recPort, cty.NumberIntVal(int64(*rec.Port)))
+		}
+	}
+
+	id := domain
+	if customer != "" {
+		id = customer + ":" + domain
+	}
+
+	root.AppendNewline()
+	imp := root.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceDomainRecordType},
+		hcl.TraverseAttr{Name: name},
+	})
+	imp.SetAttributeValue("id", cty.StringVal(id))
+	root.AppendNewline()
+
+	return f.Bytes()
+}
+
+// resourceName converts a domain into a valid resource name
+func resourceName(domain string) string {
+	name := invalidLabelChars.ReplaceAllString(domain, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// uniqueResourceName suffixes the name with a counter when it is already used,
+// as different domains such as a.b.com and a_b.com map to the same name
+func uniqueResourceName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	used[unique] = true
+	return unique
+}
@@ -0,0 +1,77 @@
+package godaddy
+
+import (
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainRecordConfig(t *testing.T) {
+	port := 389
+	records := []*api.DomainRecord{
+		{Name: "www", Type: api.CNameType, Data: "@", TTL: 3600},
+		{Name: "@", Type: api.TXTType, Data: `v=spf1 include:"_spf.example.com" ~all`, TTL: 600},
+		{Name: "@", Type: api.MXType, Data: "mx.example.com", TTL: 600, Priority: 10},
+		{Name: "@", Type: api.SRVType, Data: "host.example.com", TTL: 3600, Service: "_ldap", Protocol: "_tcp", Port: &port},
+	}
+
+	expected := `resource "godaddy_domain_record" "_1example_com" {
+  domain   = "1example.com"
+  customer = "1234"
+
+  record {
+    name = "www"
+    type = "CNAME"
+    data = "@"
+    ttl  = 3600
+  }
+
+  record {
+    name     = "@"
+    type     = "MX"
+    data     = "mx.example.com"
+    ttl      = 600
+    priority = 10
+  }
+
+  record {
+    name     = "@"
+    type     = "SRV"
+    data     = "host.example.com"
+    ttl      = 3600
+    service  = "_ldap"
+    protocol = "_tcp"
+    port     = 389
+  }
+
+  record {
+    name = "@"
+    type = "TXT"
+    data = "v=spf1 include:\"_spf.example.com\" ~all"
+    ttl  = 600
+  }
+}
+
+import {
+  to = godaddy_domain_record._1example_com
+  id = "1234:1example.com"
+}
+
+`
+	assert.Equal(t, expected, string(domainRecordConfig("1234", "1example.com", resourceName("1example.com"), records)))
+}
+
+func TestResourceName(t *testing.T) {
+	assert.Equal(t, "example_com", resourceName("example.com"))
+	assert.Equal(t, "sub-domain_example_co_uk", resourceName("sub-domain.example.co.uk"))
+	assert.Equal(t, "_123_com", resourceName("123.com"))
+}
+
+func TestUniqueResourceName(t *testing.T) {
+	used := make(map[string]bool)
+	assert.Equal(t, "a_b_com", uniqueResourceName(resourceName("a.b.com"), used))
+	assert.Equal(t, "a_b_com_2", uniqueResourceName(resourceName("a_b.com"), used))
+	assert.Equal(t, "a_b_com_3", uniqueResourceName(resourceName("a.b.com"), used))
+	assert.Equal(t, "a-b_com", uniqueResourceName(resourceName("a-b.com"), used))
+}
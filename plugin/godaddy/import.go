@@ -0,0 +1,90 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parseImportID splits an import ID of the form `example.com` or
+// `customer:example.com` into its customer and domain parts
+func parseImportID(id string) (string, string, error) {
+	var customer, domain string
+
+	parts := strings.Split(strings.TrimSpace(id), ":")
+	switch len(parts) {
+	case 1:
+		domain = parts[0]
+	case 2:
+		customer, domain = strings.TrimSpace(parts[0]), parts[1]
+		if customer == "" {
+			return "", "", fmt.Errorf("invalid import id (%s): customer must not be empty, expected customer:domain", id)
+		}
+	default:
+		return "", "", fmt.Errorf("invalid import id (%s): expected domain or customer:domain", id)
+	}
+
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return "", "", fmt.Errorf("invalid import id (%s): domain must not be empty", id)
+	}
+
+	return customer, domain, nil
+}
+
+// importDomainState resolves the domain referenced by the import ID and
+// populates the attributes the resource read functions rely upon
+func importDomainState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := importDomain(ctx, d, meta.(*api.Client)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importDomainZoneState imports a zone, treating the nameservers in use as
+// the ones captured at create time
+func importDomainZoneState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	domain, err := importDomain(ctx, d, meta.(*api.Client))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.Set(attrOriginalNameservers, domain.NameServers); err != nil {
+		return nil, err
+	}
+	if err = d.Set(attrRestoreOnDestroy, restoreKeep); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func importDomain(ctx context.Context, d *schema.ResourceData, client *api.Client) (*api.Domain, error) {
+	customer, name, err := parseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("Importing", name, "...")
+	domain, err := client.GetDomain(ctx, customer, name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find domain (%s): %s", name, err.Error())
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if err = d.Set(attrDomain, name); err != nil {
+		return nil, err
+	}
+	if err = d.Set(zattrCustomer, customer); err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
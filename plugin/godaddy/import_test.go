@@ -0,0 +1,40 @@
+package godaddy
+
+import (
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		ID       string
+		Customer string
+		Domain   string
+		Negative bool
+	}{
+		{"Given a domain", "example.com", "", "example.com", false},
+		{"Given a customer and domain", "1234:example.com", "1234", "example.com", false},
+		{"Given surrounding whitespace", " 1234 : example.com ", "1234", "example.com", false},
+		{"Given an empty id", "", "", "", true},
+		{"Given an empty customer", ":example.com", "", "", true},
+		{"Given an empty domain", "1234:", "", "", true},
+		{"Given too many parts", "1234:example.com:extra", "", "", true},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			customer, domain, err := parseImportID(test.ID)
+			if err != nil {
+				if !test.Negative {
+					t.Errorf("failed to parse import id: %s", err)
+				}
+				return
+			}
+			if test.Negative {
+				t.Errorf("expected %q to be rejected", test.ID)
+			}
+			if customer != test.Customer || domain != test.Domain {
+				t.Errorf("expected %q/%q, got %q/%q", test.Customer, test.Domain, customer, domain)
+			}
+		})
+	}
+}
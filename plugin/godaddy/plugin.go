@@ -1,6 +1,40 @@
 package godaddy
 
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
 const (
-	// BaseURL points to the GoDaddy API's base
-	BaseURL = "https://api.godaddy.com/v1"
+	// DefaultEnvironment is the GoDaddy environment used unless configured otherwise
+	DefaultEnvironment = api.EnvProduction
+
+	// defaultPendingTimeout bounds the wait for pending domain changes unless
+	// a resource configures its own timeouts
+	defaultPendingTimeout = 10 * time.Minute
 )
+
+// pendingTimeouts are the timeouts of resources that wait for pending domain
+// changes before writing to the domain
+func pendingTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultPendingTimeout),
+		Update: schema.DefaultTimeout(defaultPendingTimeout),
+		Delete: schema.DefaultTimeout(defaultPendingTimeout),
+	}
+}
+
+// waitForDomain blocks until GoDaddy has completed the pending changes of the domain
+func waitForDomain(ctx context.Context, client *api.Client, customer, domain string, timeout time.Duration) (*api.Domain, error) {
+	log.Println("Waiting for", domain, "pending changes...")
+	d, err := client.WaitForDomain(ctx, customer, domain, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't find domain (%s): %w", domain, err)
+	}
+	return d, nil
+}
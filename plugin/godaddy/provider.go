@@ -1,56 +1,169 @@
 package godaddy
 
 import (
+	"context"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
+	return New("dev")()
+}
+
+// New returns a function that builds the provider for the provided version,
+// which is reported in the User-Agent of every API request.
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		p := newProvider()
+		p.ConfigureContextFunc = providerConfigure(p, version)
+		return p
+	}
+}
+
+func newProvider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("GODADDY_API_KEY", nil),
-				Description: "GoDaddy API Key.",
+				Description: "GoDaddy API Key (defaults to the key of the shared credentials profile).",
 			},
 
 			"secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("GODADDY_API_SECRET", nil),
-				Description: "GoDaddy API Secret.",
-			},
-			// "customerid": {
-			// 	Type:        schema.TypeString,
-			// 	Required:    true,
-			// 	DefaultFunc: schema.EnvDefaultFunc("GODADDY_API_CUSTOMER_ID", nil),
-			// 	Description: "GoDaddy Customer ID.",
-			// },
+				Description: "GoDaddy API Secret (defaults to the secret of the shared credentials profile).",
+			},
+			"customer": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GODADDY_API_CUSTOMER_ID", nil),
+				Description: "GoDaddy Customer ID used by resources that do not specify one.",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GODADDY_PROFILE", nil),
+				Description: "Profile of the shared credentials file (defaults to `default`).",
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GODADDY_SHARED_CREDENTIALS_FILE", DefaultCredentialsFile),
+				Description: "Path to the shared credentials file, in INI or JSON format.",
+			},
+			"environment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("GODADDY_ENVIRONMENT", nil),
+				ValidateFunc: validation.StringInSlice([]string{api.EnvProduction, api.EnvOTE}, false),
+				Description:  "GoDaddy environment, `production` or `ote` (defaults to production). API keys are issued per environment.",
+			},
+			"rate_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("GODADDY_RATE_LIMIT", nil),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of API requests per minute (defaults to 60).",
+			},
 			"baseurl": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "https://api.godaddy.com",
-				Description: "GoDaddy Base Url(defaults to production).",
+				Description: "GoDaddy Base Url, overrides the url of the selected environment.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GODADDY_PROXY_URL", nil),
+				Description: "Proxy Url for all API requests (defaults to the HTTPS_PROXY environment variable).",
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GODADDY_CA_BUNDLE", nil),
+				Description: "Path to a PEM encoded CA bundle trusted in addition to the system certificates.",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the validation of the API credentials while configuring the provider.",
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     int(api.DefaultTimeout.Seconds()),
+				Description: "Timeout of a single API request in seconds.",
 			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"godaddy_domain_zone": resourceDomainZone(),
-			"godaddy_domain_record": resourceDomainRecord(),
+			"godaddy_domain_zone":         resourceDomainZone(),
+			"godaddy_domain_record":       resourceDomainRecord(),
+			"godaddy_domain_settings":     resourceDomainSettings(),
+			"godaddy_domain_contacts":     resourceDomainContacts(),
+			"godaddy_domain_registration": resourceDomainRegistration(),
+			"godaddy_domain_renewal":      resourceDomainRenewal(),
+			"godaddy_domain_dnssec":       resourceDomainDNSSEC(),
+			"godaddy_domain_forwarding":   resourceDomainForwarding(),
+			"godaddy_domain_transfer_in":  resourceDomainTransferIn(),
+			"godaddy_domain_transfer_out": resourceDomainTransferOut(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"godaddy_domain":              dataSourceDomain(),
+			"godaddy_domain_availability": dataSourceDomainAvailability(),
+			"godaddy_domain_suggestions":  dataSourceDomainSuggestions(),
+			"godaddy_tlds":                dataSourceTLDs(),
 		},
-
-		ConfigureFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
-		Key:     d.Get("key").(string),
-		Secret:  d.Get("secret").(string),
-//		CustomerID:  d.Get("customerid").(string),
-		BaseURL: d.Get("baseurl").(string),
-	}
+func providerConfigure(p *schema.Provider, version string) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		config := Config{
+			Key:            d.Get("key").(string),
+			Secret:         d.Get("secret").(string),
+			Customer:       d.Get("customer").(string),
+			Environment:    d.Get("environment").(string),
+			RateLimit:      d.Get("rate_limit").(int),
+			BaseURL:        d.Get("baseurl").(string),
+			ProxyURL:       d.Get("proxy_url").(string),
+			CABundle:       d.Get("ca_bundle").(string),
+			RequestTimeout: time.Duration(d.Get("request_timeout").(int)) * time.Second,
+			UserAgent:      p.UserAgent("terraform-provider-godaddy", version),
+		}
+
+		if err := config.LoadProfile(d.Get("shared_credentials_file").(string), d.Get("profile").(string)); err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		if config.Key == "" || config.Secret == "" {
+			return nil, diag.Errorf("GoDaddy API key and secret must be set through the provider configuration, " +
+				"the GODADDY_API_KEY and GODADDY_API_SECRET environment variables or a shared credentials profile")
+		}
 
-	return config.Client()
+		client, err := config.Client()
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		if d.Get("skip_credentials_validation").(bool) {
+			return client, nil
+		}
+
+		diags := config.ValidateCredentials(ctx, client)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		return client, diags
+	}
 }
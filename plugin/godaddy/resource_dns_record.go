@@ -9,10 +9,17 @@ import (
 	"github.com/b13f/terraform-provider-godaddy/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	attrRecord = "record"
+	attrRecord        = "record"
+	attrParkedRecords = "parked_records"
+
+	// parkedIgnore leaves GoDaddy parked page records out of state
+	parkedIgnore = "ignore"
+	// parkedManage tracks GoDaddy parked page records like any other record
+	parkedManage = "manage"
 
 	recName     = "name"
 	recType     = "type"
@@ -26,15 +33,30 @@ const (
 )
 
 type domainRecordResource struct {
-	Customer string
-	Domain   string
-	Records  []*api.DomainRecord
+	Customer      string
+	Domain        string
+	Records       []*api.DomainRecord
+	ARecords      []string
+	ParkedRecords string
+}
+
+// resourceGetter is satisfied by both schema.ResourceData and schema.ResourceDiff
+type resourceGetter interface {
+	GetOk(string) (interface{}, bool)
 }
 
-func newDomainRecordResource(d *schema.ResourceData) (*domainRecordResource, error) {
+func newDomainRecordResource(d resourceGetter) (*domainRecordResource, error) {
 	var err error
 	r := &domainRecordResource{}
 
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
 	if attr, ok := d.GetOk(attrRecord); ok {
 		records := attr.(*schema.Set).List()
 		r.Records = make([]*api.DomainRecord, len(records))
@@ -62,14 +84,29 @@ func newDomainRecordResource(d *schema.ResourceData) (*domainRecordResource, err
 		}
 	}
 
+	if attr, ok := d.GetOk(attrAddresses); ok {
+		for _, item := range attr.([]interface{}) {
+			r.ARecords = append(r.ARecords, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk(attrParkedRecords); ok {
+		r.ParkedRecords = attr.(string)
+	}
+
+	err = r.mergeRecords(r.ARecords, api.NewARecord)
 	return r, err
 }
 
-func (r *domainRecordResource) mergeRecords(list []string, factory api.RecordFactory) {
+func (r *domainRecordResource) mergeRecords(list []string, factory api.RecordFactory) error {
 	for _, data := range list {
-		record, _ := factory(data)
+		record, err := factory(data)
+		if err != nil {
+			return err
+		}
 		r.Records = append(r.Records, record)
 	}
+	return nil
 }
 
 func resourceDomainRecord() *schema.Resource {
@@ -78,11 +115,39 @@ func resourceDomainRecord() *schema.Resource {
 		ReadContext:   resourceDomainRecordRead,
 		UpdateContext: resourceDomainRecordUpdate,
 		DeleteContext: resourceDomainRecordRestore,
+		CustomizeDiff: resourceDomainRecordCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDomainState,
 		},
+		Timeouts: pendingTimeouts(),
 
 		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrAddresses: {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: fmt.Sprintf("Shorthand for `@` A records with the default TTL (%d). "+
+					"When set, those records are tracked here instead of in `record`, which can't contain them.", api.DefaultTTL),
+			},
+			attrParkedRecords: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      parkedIgnore,
+				ValidateFunc: validation.StringInSlice([]string{parkedIgnore, parkedManage}, false),
+				Description: "Policy for the A records GoDaddy creates for its parked page: `ignore` leaves them out of state " +
+					"(they are replaced as soon as A records are written), `manage` tracks them like any other record.",
+			},
 			attrRecord: {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -135,10 +200,35 @@ func resourceDomainRecord() *schema.Resource {
 	}
 }
 
-func resourceDomainRecordRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// resourceDomainRecordCustomizeDiff rejects record sets GoDaddy would refuse
+// before any of the record types are written, as well as records that could
+// not be read back the way they are configured
+func resourceDomainRecordCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(attrRecord) || !d.NewValueKnown(attrAddresses) {
+		return nil
+	}
+
+	r, err := newDomainRecordResource(d)
+	if err != nil {
+		return err
+	}
+
+	// the default A records would be read back into the addresses, the ones
+	// of the record set come first as the addresses are merged after them
+	if len(r.ARecords) > 0 {
+		for _, rec := range r.Records[:len(r.Records)-len(r.ARecords)] {
+			if api.IsDefaultARecord(rec) {
+				return fmt.Errorf("record %s %s %s: @ A records with the default TTL (%d) go in %s when it is set",
+					rec.Type, rec.Name, rec.Data, api.DefaultTTL, attrAddresses)
+			}
+		}
+	}
+
+	return api.ValidateRecordSet(r.Records)
+}
+
+func resourceDomainRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.Client)
-	customer := d.Get(zattrCustomer).(string)
-	domain := d.Get(attrDomain).(string)
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -147,24 +237,18 @@ func resourceDomainRecordRead(_ context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	// Importer support
-	if domain == "" {
-		r.Domain = d.Id()
-		domain = r.Domain
-	}
-
-	log.Println("Fetching", domain, "records...")
-	records, err := client.GetDomainRecords(customer, domain)
+	log.Println("Fetching", r.Domain, "records...")
+	records, err := client.GetDomainRecords(ctx, r.Customer, r.Domain)
 
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("couldn't find domain record (%s): %s", domain, err.Error()))
+		return diag.FromErr(fmt.Errorf("couldn't find domain record (%s): %s", r.Domain, err.Error()))
 	}
 
 	if err := populateResourceDataFromResponse(records, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err := populateDomainInfo(client, r, d); err != nil {
+	if err := populateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 	return diags
@@ -180,13 +264,17 @@ func resourceDomainRecordCreate(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
-	if err = populateDomainInfo(client, r, d); err != nil {
+	if _, err = waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = populateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 
 	log.Println("Creating", r.Domain, "domain records...")
 
-	err = client.ReplaceDomainRecords(r.Customer, r.Domain, r.Records)
+	err = client.ReplaceDomainRecords(ctx, r.Customer, r.Domain, r.Records)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -204,13 +292,17 @@ func resourceDomainRecordUpdate(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(err)
 	}
 
-	if err = populateDomainInfo(client, r, d); err != nil {
+	if _, err = waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = populateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 
 	log.Println("Updating", r.Domain, "domain records...")
 
-	err = client.ReplaceDomainRecords(r.Customer, r.Domain, r.Records)
+	err = client.ReplaceDomainRecords(ctx, r.Customer, r.Domain, r.Records)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -226,12 +318,16 @@ func resourceDomainRecordRestore(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	if err = populateDomainInfo(client, r, d); err != nil {
+	if _, err = waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = populateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 
 	log.Println("Restoring", r.Domain, "domain records...")
-	err = client.AddDomainRecords(r.Customer, r.Domain, r.Records)
+	err = client.AddDomainRecords(ctx, r.Customer, r.Domain, r.Records)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -241,12 +337,12 @@ func resourceDomainRecordRestore(ctx context.Context, d *schema.ResourceData, me
 
 }
 
-func populateDomainInfo(client *api.Client, r *domainRecordResource, d *schema.ResourceData) error {
+func populateDomainInfo(ctx context.Context, client *api.Client, r *domainRecordResource, d *schema.ResourceData) error {
 	var err error
 	var domain *api.Domain
 
 	log.Println("Fetching", r.Domain, "info...")
-	domain, err = client.GetDomain(r.Customer, r.Domain)
+	domain, err = client.GetDomain(ctx, r.Customer, r.Domain)
 	if err != nil {
 		return fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error())
 	}
@@ -257,36 +353,50 @@ func populateDomainInfo(client *api.Client, r *domainRecordResource, d *schema.R
 }
 
 func populateResourceDataFromResponse(recs []*api.DomainRecord, r *domainRecordResource, d *schema.ResourceData) error {
+	records, addresses := stateRecords(recs, len(r.ARecords) > 0, r.ParkedRecords)
+
+	if err := d.Set(attrRecord, flattenRecords(records)); err != nil {
+		return err
+	}
+
+	if err := d.Set(attrAddresses, addresses); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// stateRecords splits the fetched records into the ones tracked by the record
+// set and, when the addresses shorthand is in use, the default A records.
+// Parked page records are dropped unless their policy is to manage them.
+func stateRecords(recs []*api.DomainRecord, useAddresses bool, parked string) ([]*api.DomainRecord, []string) {
 	aRecords := make([]string, 0)
 	//nsRecords := make([]string, 0)
-	domain := d.Get(attrDomain).(string)
 	records := make([]*api.DomainRecord, 0)
 
 	for _, rec := range recs {
 		switch {
 		// case api.IsDefaultNSRecord(rec):
 		// 	nsRecords = append(nsRecords, rec.Data)
-		case api.IsDefaultARecord(rec):
+		case api.IsParkedRecord(rec) && parked != parkedManage:
+			log.Println("Ignoring parked record", rec.Name, rec.Data)
+		case api.IsDefaultARecord(rec) && useAddresses:
 			aRecords = append(aRecords, rec.Data)
 		default:
 			records = append(records, rec)
 		}
 	}
 
-	if err := d.Set(attrRecord, flattenRecords(records)); err != nil {
-		return err
-	}
-
-	if domain == "" {
-		d.Set(attrDomain, d.Id())
-	}
-
-	return nil
+	return records, aRecords
 }
 
 func flattenRecords(list []*api.DomainRecord) []map[string]interface{} {
 	result := make([]map[string]interface{}, len(list))
 	for i, r := range list {
+		port := api.DefaultPort
+		if r.Port != nil {
+			port = *r.Port
+		}
 		result[i] = map[string]interface{}{
 			recName:     r.Name,
 			recType:     r.Type,
@@ -294,7 +404,7 @@ func flattenRecords(list []*api.DomainRecord) []map[string]interface{} {
 			recTTL:      r.TTL,
 			recPriority: r.Priority,
 			recWeight:   r.Weight,
-			recPort:     r.Port,
+			recPort:     port,
 			recService:  r.Service,
 			recProto:    r.Protocol,
 		}
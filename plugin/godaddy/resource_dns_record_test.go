@@ -0,0 +1,67 @@
+package godaddy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateRecords(t *testing.T) {
+	apex := &api.DomainRecord{Name: api.Ptr, Type: api.AType, Data: "192.168.1.2", TTL: api.DefaultTTL}
+	short := &api.DomainRecord{Name: api.Ptr, Type: api.AType, Data: "192.168.1.3", TTL: 600}
+	parked := &api.DomainRecord{Name: api.Ptr, Type: api.AType, Data: api.ParkedData, TTL: 600}
+	www := &api.DomainRecord{Name: "www", Type: api.CNameType, Data: "@", TTL: api.DefaultTTL}
+	recs := []*api.DomainRecord{apex, short, parked, www}
+
+	t.Run("Given records without the addresses shorthand", func(t *testing.T) {
+		records, addresses := stateRecords(recs, false, parkedIgnore)
+		assert.Equal(t, []*api.DomainRecord{apex, short, www}, records)
+		assert.Empty(t, addresses)
+	})
+
+	t.Run("Given records with the addresses shorthand", func(t *testing.T) {
+		records, addresses := stateRecords(recs, true, parkedIgnore)
+		assert.Equal(t, []*api.DomainRecord{short, www}, records)
+		assert.Equal(t, []string{apex.Data}, addresses)
+	})
+
+	t.Run("Given managed parked records", func(t *testing.T) {
+		records, _ := stateRecords(recs, false, parkedManage)
+		assert.Equal(t, recs, records)
+	})
+}
+
+func TestDomainRecordCustomizeDiff(t *testing.T) {
+	var criteria = []struct {
+		Name      string
+		Record    map[string]interface{}
+		Addresses []interface{}
+		Negative  bool
+	}{
+		{"Given a default A record without addresses", map[string]interface{}{recName: api.Ptr, recType: api.AType, recData: "192.168.1.2"}, nil, false},
+		{"Given a default A record with addresses", map[string]interface{}{recName: api.Ptr, recType: api.AType, recData: "192.168.1.2"}, []interface{}{"192.168.1.3"}, true},
+		{"Given a short lived A record with addresses", map[string]interface{}{recName: api.Ptr, recType: api.AType, recData: "192.168.1.2", recTTL: 600}, []interface{}{"192.168.1.3"}, false},
+		{"Given a named A record with addresses", map[string]interface{}{recName: "www", recType: api.AType, recData: "192.168.1.2"}, []interface{}{"192.168.1.3"}, false},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			config := map[string]interface{}{
+				attrDomain: "example.com",
+				attrRecord: []interface{}{test.Record},
+			}
+			if test.Addresses != nil {
+				config[attrAddresses] = test.Addresses
+			}
+
+			_, err := resourceDomainRecord().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			if test.Negative {
+				assert.ErrorContains(t, err, attrAddresses)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -10,19 +10,31 @@ import (
 	"github.com/b13f/terraform-provider-godaddy/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	zattrCustomer   = "customer"
-	attrDomain      = "domain"
-	attrAddresses   = "addresses"
-	attrNameservers = "nameservers"
+	zattrCustomer           = "customer"
+	attrDomain              = "domain"
+	attrAddresses           = "addresses"
+	attrNameservers         = "nameservers"
+	attrOriginalNameservers = "original_nameservers"
+	attrRestoreOnDestroy    = "restore_on_destroy"
+
+	// restoreDefault switches the domain back to the GoDaddy nameservers
+	restoreDefault = "default"
+	// restoreOriginal switches the domain back to the nameservers found at create time
+	restoreOriginal = "original"
+	// restoreKeep leaves the nameservers untouched
+	restoreKeep = "keep"
 )
 
 type domainZoneResource struct {
-	Customer  string
-	Domain    string
-	NSRecords []string
+	Customer      string
+	Domain        string
+	NSRecords     []string
+	OriginalNS    []string
+	RestoreOnDrop string
 }
 
 func newDomainZoneResource(d *schema.ResourceData) (*domainZoneResource, error) {
@@ -43,6 +55,16 @@ func newDomainZoneResource(d *schema.ResourceData) (*domainZoneResource, error)
 		}
 	}
 
+	if attr, ok := d.GetOk(attrOriginalNameservers); ok {
+		for _, item := range attr.([]interface{}) {
+			r.OriginalNS = append(r.OriginalNS, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk(attrRestoreOnDestroy); ok {
+		r.RestoreOnDrop = attr.(string)
+	}
+
 	return r, err
 }
 
@@ -53,8 +75,9 @@ func resourceDomainZone() *schema.Resource {
 		UpdateContext: resourceDomainZoneUpdate,
 		DeleteContext: resourceDomainZoneRestore,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDomainZoneState,
 		},
+		Timeouts: pendingTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			// Required
@@ -71,14 +94,26 @@ func resourceDomainZone() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			attrOriginalNameservers: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Nameservers the domain used before it was managed by this resource.",
+			},
+			attrRestoreOnDestroy: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      restoreKeep,
+				ValidateFunc: validation.StringInSlice([]string{restoreDefault, restoreOriginal, restoreKeep}, false),
+				Description: "Nameservers to leave behind on destroy: `default` restores the GoDaddy nameservers, " +
+					"`original` restores the nameservers captured at create time and `keep` leaves them as they are.",
+			},
 		},
 	}
 }
 
-func resourceDomainZoneRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceDomainZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.Client)
-	//customer := d.Get(zattrCustomer).(string)
-	domain := d.Get(attrDomain).(string)
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
@@ -87,17 +122,7 @@ func resourceDomainZoneRead(_ context.Context, d *schema.ResourceData, meta inte
 		return diag.FromErr(err)
 	}
 
-	log.Println("IIIIIIIIIIIIIIIIIIIIIIIIIIMMMM %+v", d.Id())
-
-	// Importer support
-	if domain == "" {
-		r.Domain = d.Id()
-		domain = r.Domain
-	}
-
-	log.Println("Fetching", domain, "records...")
-
-	if err := zpopulateDomainInfo(client, r, d); err != nil {
+	if err := zpopulateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 	return diags
@@ -105,27 +130,32 @@ func resourceDomainZoneRead(_ context.Context, d *schema.ResourceData, meta inte
 
 func resourceDomainZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.Client)
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
 	r, err := newDomainZoneResource(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = zpopulateDomainInfo(client, r, d); err != nil {
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Println("Creating", r.Domain, "domain records...")
+	d.SetId(strconv.FormatInt(domain.ID, 10))
 
-	if err != nil {
+	// keep track of the nameservers in use so that they can be restored on destroy
+	if err = d.Set(attrOriginalNameservers, domain.NameServers); err != nil {
 		return diag.FromErr(err)
 	}
-	// Implement read to populate the Terraform state to its current state after the resource creation
-	resourceDomainZoneRead(ctx, d, meta)
 
-	return diags
+	if len(r.NSRecords) > 0 {
+		log.Println("Updating", r.Domain, "nameservers...")
+		if err = client.UpdateNSDomain(ctx, r.NSRecords, r.Customer, r.Domain); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Implement read to populate the Terraform state to its current state after the resource creation
+	return resourceDomainZoneRead(ctx, d, meta)
 }
 
 func resourceDomainZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -135,14 +165,18 @@ func resourceDomainZoneUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	if err = zpopulateDomainInfo(client, r, d); err != nil {
+	if _, err = waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = zpopulateDomainInfo(ctx, client, r, d); err != nil {
 		return diag.FromErr(err)
 	}
 	//_, _ = client.GetShoppers("")
 
 	//err = client.UpdateDomainInfo(r.Domain, r.NSRecords)
 
-	err = client.UpdateNSDomain(r.NSRecords, r.Customer, r.Domain)
+	err = client.UpdateNSDomain(ctx, r.NSRecords, r.Customer, r.Domain)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -167,29 +201,48 @@ func resourceDomainZoneUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 func resourceDomainZoneRestore(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api.Client)
+	var diags diag.Diagnostics
+
 	r, err := newDomainZoneResource(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = zpopulateDomainInfo(client, r, d); err != nil {
+	var ns []string
+	switch r.RestoreOnDrop {
+	case restoreDefault:
+		ns = api.DefaultNameServers
+	case restoreOriginal:
+		ns = r.OriginalNS
+		if len(ns) == 0 {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "No original nameservers to restore",
+				Detail:   fmt.Sprintf("The nameservers of %s were not captured at create time and have been left as they are.", r.Domain),
+			})
+		}
+	default:
+		return diags
+	}
+
+	if _, err = waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutDelete)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err != nil {
+	log.Println("Restoring", r.Domain, "nameservers...")
+	if err = client.UpdateNSDomain(ctx, ns, r.Customer, r.Domain); err != nil {
 		return diag.FromErr(err)
 	}
-	// Implement read to populate the Terraform state to its current state after the resource creation
-	return resourceDomainZoneRead(ctx, d, meta)
 
+	return diags
 }
 
-func zpopulateDomainInfo(client *api.Client, r *domainZoneResource, d *schema.ResourceData) error {
+func zpopulateDomainInfo(ctx context.Context, client *api.Client, r *domainZoneResource, d *schema.ResourceData) error {
 	var err error
 	var domain *api.Domain
 
 	log.Println("Fetching", r.Domain, "info...")
-	domain, err = client.GetDomain(r.Customer, r.Domain)
+	domain, err = client.GetDomain(ctx, r.Customer, r.Domain)
 	if err != nil {
 		return fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error())
 	}
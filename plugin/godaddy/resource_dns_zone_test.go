@@ -0,0 +1,131 @@
+package godaddy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// zoneServer serves a domain delegated to the nameservers and records the
+// nameserver updates
+type zoneServer struct {
+	sync.Mutex
+	nameservers []string
+	updates     [][]string
+}
+
+func (s *zoneServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch req.Method {
+	case http.MethodPatch:
+		var update api.DomainUpdate
+		json.NewDecoder(req.Body).Decode(&update)
+		s.nameservers = update.NameServers
+		s.updates = append(s.updates, update.NameServers)
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"domainId":    1,
+			"domain":      "example.com",
+			"status":      api.StatusActive,
+			"nameServers": s.nameservers,
+		})
+	}
+}
+
+func TestDomainZoneOriginalNameservers(t *testing.T) {
+	ctx := context.Background()
+	original := []string{"ns1.example.net", "ns2.example.net"}
+	server := &zoneServer{nameservers: original}
+	client := newTestClient(t, server)
+	resource := resourceDomainZone()
+
+	zoneConfig := func(ns ...interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			attrDomain:           "example.com",
+			attrNameservers:      ns,
+			attrRestoreOnDestroy: restoreOriginal,
+		})
+	}
+
+	// the nameservers in use are captured on create
+	diff, err := resource.Diff(ctx, nil, zoneConfig("ns1.example.org", "ns2.example.org"), client)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	state, diags := resource.Apply(ctx, nil, diff, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, [][]string{{"ns1.example.org", "ns2.example.org"}}, server.updates)
+	assert.Equal(t, "1", state.ID)
+	assert.Equal(t, "ns1.example.org", state.Attributes[attrNameservers+".0"])
+	assert.Equal(t, "ns1.example.net", state.Attributes[attrOriginalNameservers+".0"])
+	assert.Equal(t, "ns2.example.net", state.Attributes[attrOriginalNameservers+".1"])
+
+	// and kept as they were on update
+	diff, err = resource.Diff(ctx, state, zoneConfig("ns3.example.org", "ns4.example.org"), client)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	state, diags = resource.Apply(ctx, state, diff, client)
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, server.updates, 2) {
+		assert.Equal(t, []string{"ns3.example.org", "ns4.example.org"}, server.updates[1])
+	}
+	assert.Equal(t, "ns3.example.org", state.Attributes[attrNameservers+".0"])
+	assert.Equal(t, "ns1.example.net", state.Attributes[attrOriginalNameservers+".0"])
+	assert.Equal(t, "ns2.example.net", state.Attributes[attrOriginalNameservers+".1"])
+}
+
+func TestDomainZoneRestore(t *testing.T) {
+	original := []string{"ns1.example.net", "ns2.example.net"}
+
+	var criteria = []struct {
+		Name     string
+		Restore  string
+		Original []string
+		Updates  [][]string
+		Warning  bool
+	}{
+		{"Given the default nameservers to restore", restoreDefault, original, [][]string{api.DefaultNameServers}, false},
+		{"Given the original nameservers to restore", restoreOriginal, original, [][]string{original}, false},
+		{"Given no original nameservers captured", restoreOriginal, nil, nil, true},
+		{"Given the nameservers to keep", restoreKeep, original, nil, false},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			server := &zoneServer{nameservers: []string{"ns1.example.org", "ns2.example.org"}}
+			client := newTestClient(t, server)
+
+			d := schema.TestResourceDataRaw(t, resourceDomainZone().Schema, map[string]interface{}{
+				attrDomain:           "example.com",
+				attrRestoreOnDestroy: test.Restore,
+			})
+			d.SetId("1")
+			assert.Nil(t, d.Set(attrOriginalNameservers, test.Original))
+
+			diags := resourceDomainZoneRestore(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			if test.Warning {
+				if assert.Len(t, diags, 1) {
+					assert.Equal(t, diag.Warning, diags[0].Severity)
+					assert.Equal(t, "No original nameservers to restore", diags[0].Summary)
+				}
+			} else {
+				assert.Empty(t, diags)
+			}
+			assert.Equal(t, test.Updates, server.updates)
+		})
+	}
+}
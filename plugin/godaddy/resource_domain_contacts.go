@@ -0,0 +1,316 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	attrRegistrant             = "registrant"
+	attrAdmin                  = "admin"
+	attrTech                   = "tech"
+	attrBilling                = "billing"
+	attrDomainNameVerification = "domain_name_verification"
+	attrRealNameVerification   = "real_name_verification"
+
+	contactNameFirst    = "name_first"
+	contactNameMiddle   = "name_middle"
+	contactNameLast     = "name_last"
+	contactOrganization = "organization"
+	contactJobTitle     = "job_title"
+	contactEmail        = "email"
+	contactPhone        = "phone"
+	contactFax          = "fax"
+	contactAddress1     = "address1"
+	contactAddress2     = "address2"
+	contactCity         = "city"
+	contactState        = "state"
+	contactPostalCode   = "postal_code"
+	contactCountry      = "country"
+)
+
+// contactRoles are the contact blocks of the resource in the order GoDaddy lists them
+var contactRoles = []string{attrRegistrant, attrAdmin, attrTech, attrBilling}
+
+type domainContactsResource struct {
+	Customer string
+	Domain   string
+	Contacts api.DomainContacts
+}
+
+func newDomainContactsResource(d resourceGetter) *domainContactsResource {
+	r := &domainContactsResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	r.Contacts = api.DomainContacts{
+		Registrant: expandContact(d, attrRegistrant),
+		Admin:      expandContact(d, attrAdmin),
+		Tech:       expandContact(d, attrTech),
+		Billing:    expandContact(d, attrBilling),
+	}
+
+	return r
+}
+
+func resourceDomainContacts() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainContactsUpdate,
+		ReadContext:   resourceDomainContactsRead,
+		UpdateContext: resourceDomainContactsUpdate,
+		DeleteContext: resourceDomainContactsDelete,
+		CustomizeDiff: resourceDomainContactsCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDomainState,
+		},
+		Timeouts: pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrRegistrant: contactSchema(true, "Owner of the domain. Changing the registrant may require a verification before it takes effect."),
+			attrAdmin:      contactSchema(false, "Administrative contact (defaults to the contact GoDaddy has on file)."),
+			attrTech:       contactSchema(false, "Technical contact (defaults to the contact GoDaddy has on file)."),
+			attrBilling:    contactSchema(false, "Billing contact (defaults to the contact GoDaddy has on file)."),
+			attrDomainNameVerification: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the domain name verification: `APPROVED`, `PENDING` or `REJECTED`.",
+			},
+			attrRealNameVerification: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the verification of the registrant: `APPROVED`, `PENDING` or `REJECTED`.",
+			},
+		},
+	}
+}
+
+func contactSchema(required bool, description string) *schema.Schema {
+	optional := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Optional: true, Description: description}
+	}
+	mandatory := func(description string) *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Required: true, Description: description}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    required,
+		Optional:    !required,
+		Computed:    !required,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				contactNameFirst:    mandatory(""),
+				contactNameMiddle:   optional(""),
+				contactNameLast:     mandatory(""),
+				contactOrganization: optional(""),
+				contactJobTitle:     optional(""),
+				contactEmail:        mandatory(""),
+				contactPhone:        mandatory("Phone number formatted as `+<country code>.<number>`, e.g. `+1.4805058800`."),
+				contactFax:          optional("Fax number formatted as `+<country code>.<number>`."),
+				contactAddress1:     mandatory(""),
+				contactAddress2:     optional(""),
+				contactCity:         mandatory(""),
+				contactState:        mandatory(""),
+				contactPostalCode:   mandatory(""),
+				contactCountry:      mandatory("Two letter ISO 3166-1 country code, e.g. `US`."),
+			},
+		},
+	}
+}
+
+// resourceDomainContactsCustomizeDiff rejects malformed contacts at plan time.
+// Contacts with values that are not known yet are validated on apply.
+func resourceDomainContactsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	r := newDomainContactsResource(d)
+
+	for i, contact := range []*api.Contact{r.Contacts.Registrant, r.Contacts.Admin, r.Contacts.Tech, r.Contacts.Billing} {
+		role := contactRoles[i]
+		if contact == nil || !contactKnown(d, role) {
+			continue
+		}
+		if err := contact.Validate(); err != nil {
+			return fmt.Errorf("%s: %s", role, err)
+		}
+	}
+
+	return nil
+}
+
+// contactKnown reports whether all values of the contact block of the role are known
+func contactKnown(d *schema.ResourceDiff, role string) bool {
+	if !d.NewValueKnown(role) {
+		return false
+	}
+	for field := range contactSchema(false, "").Elem.(*schema.Resource).Schema {
+		if !d.NewValueKnown(fmt.Sprintf("%s.0.%s", role, field)) {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceDomainContactsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	var diags diag.Diagnostics
+
+	r := newDomainContactsResource(d)
+
+	log.Println("Fetching", r.Domain, "contacts...")
+	contacts, verifications, err := client.GetDomainContacts(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	for i, contact := range []*api.Contact{contacts.Registrant, contacts.Admin, contacts.Tech, contacts.Billing} {
+		if err = d.Set(contactRoles[i], flattenContact(contact)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err = d.Set(attrDomainNameVerification, verifications.DomainName.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set(attrRealNameVerification, verifications.RealName.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if verifications.RealName.Status == api.VerificationRejected {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Registrant verification rejected",
+			Detail:   fmt.Sprintf("GoDaddy rejected the verification of the registrant of %s. Review the registrant contact and apply it again.", r.Domain),
+		})
+	}
+
+	return diags
+}
+
+func resourceDomainContactsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainContactsResource(d)
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	log.Println("Updating", r.Domain, "contacts...")
+	if err = client.UpdateDomainContacts(ctx, r.Customer, r.Domain, &r.Contacts); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't update contacts (%s): %s", r.Domain, err.Error()))
+	}
+
+	diags := resourceDomainContactsRead(ctx, d, meta)
+	if !diags.HasError() && d.Get(attrRealNameVerification).(string) == api.VerificationPending {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Registrant verification pending",
+			Detail: fmt.Sprintf("GoDaddy is verifying the registrant of %s, which may require confirming an email sent to the registrant. "+
+				"The verification state is refreshed on the next plan.", r.Domain),
+		})
+	}
+
+	return diags
+}
+
+// resourceDomainContactsDelete stops managing the contacts, which remain as
+// they are on the domain
+func resourceDomainContactsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("Leaving", d.Get(attrDomain).(string), "contacts as they are...")
+	d.SetId("")
+	return nil
+}
+
+// expandContact reads the contact block of the role, nil if it isn't set
+func expandContact(d resourceGetter, role string) *api.Contact {
+	attr, ok := d.GetOk(role)
+	if !ok {
+		return nil
+	}
+
+	list := attr.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+
+	m := list[0].(map[string]interface{})
+	get := func(key string) string {
+		if v, ok := m[key].(string); ok {
+			return v
+		}
+		return ""
+	}
+
+	return &api.Contact{
+		NameFirst:    get(contactNameFirst),
+		NameMiddle:   get(contactNameMiddle),
+		NameLast:     get(contactNameLast),
+		Organization: get(contactOrganization),
+		JobTitle:     get(contactJobTitle),
+		Email:        get(contactEmail),
+		Phone:        get(contactPhone),
+		Fax:          get(contactFax),
+		AddressMailing: api.Address{
+			Address1:   get(contactAddress1),
+			Address2:   get(contactAddress2),
+			City:       get(contactCity),
+			State:      get(contactState),
+			PostalCode: get(contactPostalCode),
+			Country:    get(contactCountry),
+		},
+	}
+}
+
+func flattenContact(c *api.Contact) []interface{} {
+	if c == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		contactNameFirst:    c.NameFirst,
+		contactNameMiddle:   c.NameMiddle,
+		contactNameLast:     c.NameLast,
+		contactOrganization: c.Organization,
+		contactJobTitle:     c.JobTitle,
+		contactEmail:        c.Email,
+		contactPhone:        c.Phone,
+		contactFax:          c.Fax,
+		contactAddress1:     c.AddressMailing.Address1,
+		contactAddress2:     c.AddressMailing.Address2,
+		contactCity:         c.AddressMailing.City,
+		contactState:        c.AddressMailing.State,
+		contactPostalCode:   c.AddressMailing.PostalCode,
+		contactCountry:      c.AddressMailing.Country,
+	}}
+}
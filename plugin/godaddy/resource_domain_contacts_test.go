@@ -0,0 +1,89 @@
+package godaddy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandContact(t *testing.T) {
+	registrant := &api.Contact{
+		NameFirst:    "Jane",
+		NameLast:     "Doe",
+		Organization: "Example Inc.",
+		Email:        "jane@example.com",
+		Phone:        "+1.4805058800",
+		AddressMailing: api.Address{
+			Address1:   "14455 N Hayden Rd",
+			City:       "Scottsdale",
+			State:      "AZ",
+			PostalCode: "85260",
+			Country:    "US",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDomainContacts().Schema, map[string]interface{}{
+		attrDomain:     "example.com",
+		attrRegistrant: flattenContact(registrant),
+	})
+
+	r := newDomainContactsResource(d)
+	assert.Equal(t, "example.com", r.Domain)
+	assert.Equal(t, registrant, r.Contacts.Registrant)
+	assert.Nil(t, r.Contacts.Admin)
+	assert.Empty(t, flattenContact(nil))
+}
+
+func TestDomainContactsRead(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		RealName string
+		Warning  bool
+	}{
+		{"Given a pending registrant verification", api.VerificationPending, false},
+		{"Given a rejected registrant verification", api.VerificationRejected, true},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			registrant := &api.Contact{NameFirst: "Jane", NameLast: "Doe", Email: "jane@example.com"}
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"domainId":          1,
+					"domain":            "example.com",
+					"status":            api.StatusActive,
+					"contactRegistrant": registrant,
+					"verifications": map[string]interface{}{
+						"domainName": map[string]string{"status": api.VerificationApproved},
+						"realName":   map[string]string{"status": test.RealName},
+					},
+				})
+			}))
+
+			d := schema.TestResourceDataRaw(t, resourceDomainContacts().Schema, map[string]interface{}{attrDomain: "example.com"})
+			d.SetId("1")
+
+			diags := resourceDomainContactsRead(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			if test.Warning {
+				if assert.Len(t, diags, 1) {
+					assert.Equal(t, diag.Warning, diags[0].Severity)
+				}
+			} else {
+				assert.Empty(t, diags)
+			}
+
+			assert.Equal(t, "1", d.Id())
+			assert.Equal(t, "Jane", d.Get(attrRegistrant+".0."+contactNameFirst))
+			assert.Empty(t, d.Get(attrAdmin))
+			assert.Equal(t, api.VerificationApproved, d.Get(attrDomainNameVerification))
+			assert.Equal(t, test.RealName, d.Get(attrRealNameVerification))
+		})
+	}
+}
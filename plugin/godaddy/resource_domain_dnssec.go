@@ -0,0 +1,305 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dnssecAlgorithm        = "algorithm"
+	dnssecKeyTag           = "key_tag"
+	dnssecDigestType       = "digest_type"
+	dnssecDigest           = "digest"
+	dnssecFlags            = "flags"
+	dnssecPublicKey        = "public_key"
+	dnssecMaxSignatureLife = "max_signature_life"
+)
+
+type domainDNSSECResource struct {
+	Customer string
+	Domain   string
+	Records  []api.DNSSECRecord
+}
+
+func newDomainDNSSECResource(d resourceGetter) *domainDNSSECResource {
+	r := &domainDNSSECResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrRecord); ok {
+		r.Records = expandDNSSECRecords(attr.(*schema.Set).List())
+	}
+
+	return r
+}
+
+func expandDNSSECRecords(records []interface{}) []api.DNSSECRecord {
+	expanded := make([]api.DNSSECRecord, len(records))
+	for i, rec := range records {
+		data := rec.(map[string]interface{})
+		expanded[i] = api.DNSSECRecord{
+			Algorithm:        data[dnssecAlgorithm].(string),
+			KeyTag:           data[dnssecKeyTag].(int),
+			DigestType:       data[dnssecDigestType].(string),
+			Digest:           data[dnssecDigest].(string),
+			Flags:            data[dnssecFlags].(string),
+			PublicKey:        data[dnssecPublicKey].(string),
+			MaxSignatureLife: data[dnssecMaxSignatureLife].(int),
+		}
+	}
+	return expanded
+}
+
+func flattenDNSSECRecords(records []api.DNSSECRecord) []interface{} {
+	flattened := make([]interface{}, len(records))
+	for i, rec := range records {
+		flattened[i] = map[string]interface{}{
+			dnssecAlgorithm:        rec.Algorithm,
+			dnssecKeyTag:           rec.KeyTag,
+			dnssecDigestType:       rec.DigestType,
+			dnssecDigest:           rec.Digest,
+			dnssecFlags:            rec.Flags,
+			dnssecPublicKey:        rec.PublicKey,
+			dnssecMaxSignatureLife: rec.MaxSignatureLife,
+		}
+	}
+	return flattened
+}
+
+func resourceDomainDNSSEC() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainDNSSECUpdate,
+		ReadContext:   resourceDomainDNSSECRead,
+		UpdateContext: resourceDomainDNSSECUpdate,
+		DeleteContext: resourceDomainDNSSECDelete,
+		CustomizeDiff: resourceDomainDNSSECCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDomainState,
+		},
+		Timeouts: pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrRecord: {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "DS records of the domain. Records missing from the configuration are removed.",
+				Set:         hashDNSSECRecord,
+				Elem:        dnssecRecordResource(),
+			},
+		},
+	}
+}
+
+// dnssecRecordResource describes a DS record of the domain
+func dnssecRecordResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			dnssecAlgorithm: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(api.DNSSECAlgorithms, false),
+				Description:  "Signing algorithm, e.g. `ECDSAP256SHA256` for algorithm 13.",
+			},
+			dnssecKeyTag: {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 65535),
+				Description:  "Key tag of the signing key.",
+			},
+			dnssecDigestType: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(api.DNSSECDigestTypes, false),
+				Description:  "Digest type, e.g. `SHA256` for digest type 2.",
+			},
+			dnssecDigest: {
+				Type:     schema.TypeString,
+				Optional: true,
+				// GoDaddy reports digests in upper case
+				StateFunc: func(v interface{}) string {
+					return strings.ToUpper(v.(string))
+				},
+				Description: "Digest of the signing key, as a hexadecimal string.",
+			},
+			dnssecFlags: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(api.DNSSECFlags, false),
+				Description:  "Whether the key is a key signing key (`KSK`) or a zone signing key (`ZSK`).",
+			},
+			dnssecPublicKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Public key, for registries that derive the DS record themselves.",
+			},
+			dnssecMaxSignatureLife: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum lifetime of the signatures in seconds.",
+			},
+		},
+	}
+}
+
+// dnssecRecordHash hashes the attributes of a record
+var dnssecRecordHash = schema.HashResource(dnssecRecordResource())
+
+// hashDNSSECRecord hashes the record the way GoDaddy stores it, with an upper
+// case digest, so that a lower case digest doesn't replace the record
+func hashDNSSECRecord(v interface{}) int {
+	record := make(map[string]interface{})
+	for k, value := range v.(map[string]interface{}) {
+		record[k] = value
+	}
+	if digest, ok := record[dnssecDigest].(string); ok {
+		record[dnssecDigest] = strings.ToUpper(digest)
+	}
+	return dnssecRecordHash(record)
+}
+
+// resourceDomainDNSSECCustomizeDiff rejects records GoDaddy would refuse
+// before any of them are written
+func resourceDomainDNSSECCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown(attrRecord) {
+		return nil
+	}
+
+	for _, record := range newDomainDNSSECResource(d).Records {
+		if err := record.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDomainDNSSECRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainDNSSECResource(d)
+
+	log.Println("Fetching", r.Domain, "DNSSEC records...")
+	records, err := client.GetDNSSECRecords(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find DNSSEC records (%s): %s", r.Domain, err.Error()))
+	}
+
+	if err = d.Set(attrRecord, flattenDNSSECRecords(records)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceDomainDNSSECUpdate adds the configured records the domain lacks and
+// removes the ones that are no longer configured
+func resourceDomainDNSSECUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainDNSSECResource(d)
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	current, err := client.GetDNSSECRecords(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find DNSSEC records (%s): %s", r.Domain, err.Error()))
+	}
+
+	// the new records are added first, so that a key rollover never leaves the domain without DS records
+	add, remove := api.DiffDNSSECRecords(current, r.Records)
+	if len(add) > 0 {
+		log.Println("Adding", r.Domain, "DNSSEC records...")
+		if err = writeDNSSECRecords(ctx, client, r, api.ActionDNSSECCreate, add, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if len(remove) > 0 {
+		log.Println("Removing", r.Domain, "DNSSEC records...")
+		if err = writeDNSSECRecords(ctx, client, r, api.ActionDNSSECDelete, remove, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDomainDNSSECRead(ctx, d, meta)
+}
+
+// resourceDomainDNSSECDelete removes the DS records of the domain, which is
+// no longer signed once the registry drops them
+func resourceDomainDNSSECDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainDNSSECResource(d)
+
+	timeout := d.Timeout(schema.TimeoutDelete)
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, timeout); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(r.Records) > 0 {
+		log.Println("Removing", r.Domain, "DNSSEC records...")
+		if err := writeDNSSECRecords(ctx, client, r, api.ActionDNSSECDelete, r.Records, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// writeDNSSECRecords adds or removes the records, depending on the action,
+// and waits for GoDaddy to apply the change
+func writeDNSSECRecords(ctx context.Context, client *api.Client, r *domainDNSSECResource, action string, records []api.DNSSECRecord, timeout time.Duration) error {
+	// an action left over from a previous change doesn't tell this one is applied
+	after, err := client.LastActionTime(ctx, r.Customer, r.Domain, action)
+	if err != nil {
+		return fmt.Errorf("couldn't update DNSSEC records (%s): %s", r.Domain, err.Error())
+	}
+
+	if action == api.ActionDNSSECCreate {
+		err = client.AddDNSSECRecords(ctx, r.Customer, r.Domain, records)
+	} else {
+		err = client.RemoveDNSSECRecords(ctx, r.Customer, r.Domain, records)
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't update DNSSEC records (%s): %s", r.Domain, err.Error())
+	}
+
+	if _, err = client.WaitForAction(ctx, r.Customer, r.Domain, action, after, timeout); err != nil {
+		return fmt.Errorf("couldn't update DNSSEC records (%s): %s", r.Domain, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package godaddy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandDNSSECRecords(t *testing.T) {
+	records := []api.DNSSECRecord{
+		{Algorithm: "ECDSAP256SHA256", KeyTag: 2371, DigestType: "SHA256", Digest: "C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D8CBFB4DAFD5DB2F3E8CC"},
+		{Algorithm: "RSASHA256", KeyTag: 20326, Flags: "KSK", PublicKey: "AwEAAaz/tAm8yTn4Mfeh", MaxSignatureLife: 86400},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDomainDNSSEC().Schema, map[string]interface{}{
+		attrDomain: "example.com",
+		attrRecord: flattenDNSSECRecords(records),
+	})
+
+	r := newDomainDNSSECResource(d)
+	assert.Equal(t, "example.com", r.Domain)
+	assert.ElementsMatch(t, records, r.Records)
+}
+
+func TestDomainDNSSECDigestCase(t *testing.T) {
+	record := api.DNSSECRecord{Algorithm: "ECDSAP256SHA256", KeyTag: 2371, DigestType: "SHA256", Digest: "C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D8CBFB4DAFD5DB2F3E8CC"}
+	resource := resourceDomainDNSSEC()
+
+	// GoDaddy reports the digest in upper case
+	d := resource.TestResourceData()
+	d.SetId("1")
+	assert.Nil(t, d.Set(attrDomain, "example.com"))
+	assert.Nil(t, d.Set(attrRecord, flattenDNSSECRecords([]api.DNSSECRecord{record})))
+
+	lower := record
+	lower.Digest = strings.ToLower(record.Digest)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		attrDomain: "example.com",
+		attrRecord: flattenDNSSECRecords([]api.DNSSECRecord{lower}),
+	})
+
+	diff, err := resource.Diff(context.Background(), d.State(), config, nil)
+	assert.Nil(t, err)
+	assert.True(t, diff == nil || diff.Empty(), diff)
+}
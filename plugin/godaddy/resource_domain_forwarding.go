@@ -0,0 +1,299 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrSubdomain = "subdomain"
+	attrURL       = "url"
+	attrMask      = "mask"
+	attrFQDN      = "fqdn"
+
+	maskTitle       = "title"
+	maskDescription = "description"
+	maskKeywords    = "keywords"
+)
+
+// maskAttrs are the paths of the mask attributes, one of which must be set
+var maskAttrs = []string{attrMask + ".0." + maskTitle, attrMask + ".0." + maskDescription, attrMask + ".0." + maskKeywords}
+
+type domainForwardingResource struct {
+	Customer   string
+	Domain     string
+	Subdomain  string
+	Forwarding api.Forwarding
+}
+
+func newDomainForwardingResource(d resourceGetter) *domainForwardingResource {
+	r := &domainForwardingResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrSubdomain); ok {
+		r.Subdomain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrType); ok {
+		r.Forwarding.Type = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrURL); ok {
+		r.Forwarding.URL = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrMask); ok {
+		// a mask block without attributes is read as nil, the same as no mask
+		if masks := attr.([]interface{}); len(masks) > 0 && masks[0] != nil {
+			mask := masks[0].(map[string]interface{})
+			r.Forwarding.Mask = &api.ForwardingMask{
+				Title:       mask[maskTitle].(string),
+				Description: mask[maskDescription].(string),
+				Keywords:    mask[maskKeywords].(string),
+			}
+			if *r.Forwarding.Mask == (api.ForwardingMask{}) {
+				r.Forwarding.Mask = nil
+			}
+		}
+	}
+
+	return r
+}
+
+func flattenForwardingMask(mask *api.ForwardingMask) []interface{} {
+	if mask == nil || *mask == (api.ForwardingMask{}) {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		maskTitle:       mask.Title,
+		maskDescription: mask.Description,
+		maskKeywords:    mask.Keywords,
+	}}
+}
+
+func resourceDomainForwarding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainForwardingCreate,
+		ReadContext:   resourceDomainForwardingRead,
+		UpdateContext: resourceDomainForwardingUpdate,
+		DeleteContext: resourceDomainForwardingDelete,
+		CustomizeDiff: resourceDomainForwardingCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDomainForwardingState,
+		},
+		Timeouts: pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrSubdomain: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Subdomain to forward, e.g. `www` (defaults to the domain itself).",
+			},
+			attrType: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(api.ForwardTypes, false),
+				Description: "`REDIRECT_PERMANENT` (301), `REDIRECT_TEMPORARY` (302) or `MASKED`, " +
+					"which shows the URL in a frame while the address bar keeps the domain.",
+			},
+			attrURL: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "URL the visitors are forwarded to.",
+			},
+			attrMask: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Metadata of the page framing a `MASKED` forward, with at least one of its attributes set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// GoDaddy drops an empty mask, which would never match the configuration
+						maskTitle: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							AtLeastOneOf: maskAttrs,
+						},
+						maskDescription: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							AtLeastOneOf: maskAttrs,
+						},
+						maskKeywords: {
+							Type:         schema.TypeString,
+							Optional:     true,
+							AtLeastOneOf: maskAttrs,
+							Description:  "Comma separated keywords.",
+						},
+					},
+				},
+			},
+			attrFQDN: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Forwarded name.",
+			},
+		},
+	}
+}
+
+// resourceDomainForwardingCustomizeDiff rejects a mask on redirects
+func resourceDomainForwardingCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, attr := range []string{attrType, attrURL, attrMask} {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+	}
+
+	r := newDomainForwardingResource(d)
+	return r.Forwarding.Validate()
+}
+
+// importDomainForwardingState imports the forwarding of a name of the form
+// `example.com` or `www.example.com`, optionally prefixed by `customer:`. The
+// registered domain is the longest suffix of the name found in the account.
+func importDomainForwardingState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*api.Client)
+	customer, fqdn, err := parseImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	labels := strings.Split(fqdn, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		domain := strings.Join(labels[i:], ".")
+		if _, err = client.GetDomain(ctx, customer, domain); api.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't find domain (%s): %s", domain, err.Error())
+		}
+
+		log.Println("Importing", fqdn, "forwarding...")
+		d.SetId(fqdn)
+		for attr, value := range map[string]string{
+			attrDomain:    domain,
+			attrSubdomain: strings.Join(labels[:i], "."),
+			zattrCustomer: customer,
+			attrFQDN:      fqdn,
+		} {
+			if err = d.Set(attr, value); err != nil {
+				return nil, err
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return nil, fmt.Errorf("couldn't find domain (%s): no domain of the account matches it", fqdn)
+}
+
+func resourceDomainForwardingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainForwardingResource(d)
+
+	log.Println("Fetching", api.ForwardingFQDN(r.Domain, r.Subdomain), "forwarding...")
+	forwarding, err := client.GetForwarding(ctx, r.Customer, r.Domain, r.Subdomain)
+	if api.IsNotFound(err) {
+		log.Println("Forwarding of", api.ForwardingFQDN(r.Domain, r.Subdomain), "was removed, removing it from state")
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find forwarding (%s): %s", api.ForwardingFQDN(r.Domain, r.Subdomain), err.Error()))
+	}
+
+	for attr, value := range map[string]interface{}{
+		attrType: forwarding.Type,
+		attrURL:  forwarding.URL,
+		attrMask: flattenForwardingMask(forwarding.Mask),
+	} {
+		if err = d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDomainForwardingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainForwardingResource(d)
+	fqdn := api.ForwardingFQDN(r.Domain, r.Subdomain)
+
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Forwarding", fqdn, "to", r.Forwarding.URL, "...")
+	if err := client.CreateForwarding(ctx, r.Customer, r.Domain, r.Subdomain, &r.Forwarding); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't forward domain (%s): %s", fqdn, err.Error()))
+	}
+
+	d.SetId(fqdn)
+	if err := d.Set(attrFQDN, fqdn); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDomainForwardingRead(ctx, d, meta)
+}
+
+func resourceDomainForwardingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainForwardingResource(d)
+	fqdn := api.ForwardingFQDN(r.Domain, r.Subdomain)
+
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Updating", fqdn, "forwarding...")
+	if err := client.UpdateForwarding(ctx, r.Customer, r.Domain, r.Subdomain, &r.Forwarding); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't update forwarding (%s): %s", fqdn, err.Error()))
+	}
+
+	return resourceDomainForwardingRead(ctx, d, meta)
+}
+
+func resourceDomainForwardingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainForwardingResource(d)
+	fqdn := api.ForwardingFQDN(r.Domain, r.Subdomain)
+
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Removing", fqdn, "forwarding...")
+	if err := client.DeleteForwarding(ctx, r.Customer, r.Domain, r.Subdomain); err != nil && !api.IsNotFound(err) {
+		return diag.FromErr(fmt.Errorf("couldn't remove forwarding (%s): %s", fqdn, err.Error()))
+	}
+
+	d.SetId("")
+	return nil
+}
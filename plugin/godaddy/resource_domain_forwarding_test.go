@@ -0,0 +1,112 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainForwardingMask(t *testing.T) {
+	cases := map[string]struct {
+		mask     *api.ForwardingMask
+		expected *api.ForwardingMask
+	}{
+		"Given no mask":       {nil, nil},
+		"Given an empty mask": {&api.ForwardingMask{}, nil},
+		"Given a mask": {
+			&api.ForwardingMask{Title: "Example", Keywords: "example,landing"},
+			&api.ForwardingMask{Title: "Example", Keywords: "example,landing"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDomainForwarding().Schema, map[string]interface{}{
+				attrDomain:    "example.com",
+				attrSubdomain: "www",
+				attrType:      api.ForwardMasked,
+				attrURL:       "https://example.net",
+				attrMask:      flattenForwardingMask(tc.mask),
+			})
+
+			r := newDomainForwardingResource(d)
+			assert.Equal(t, "www", r.Subdomain)
+			assert.Equal(t, api.Forwarding{Type: api.ForwardMasked, URL: "https://example.net", Mask: tc.expected}, r.Forwarding)
+		})
+	}
+}
+
+func TestDomainForwardingEmptyMask(t *testing.T) {
+	cases := map[string]struct {
+		mask  interface{}
+		valid bool
+	}{
+		"Given no mask block":    {nil, true},
+		"Given an empty mask":    {[]interface{}{map[string]interface{}{}}, false},
+		"Given a mask with text": {[]interface{}{map[string]interface{}{maskKeywords: "example"}}, true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{attrDomain: "example.com", attrType: api.ForwardMasked, attrURL: "https://example.net"}
+			if tc.mask != nil {
+				config[attrMask] = tc.mask
+			}
+
+			diags := resourceDomainForwarding().Validate(terraform.NewResourceConfigRaw(config))
+			assert.Equal(t, !tc.valid, diags.HasError(), diags)
+		})
+	}
+}
+
+func TestImportDomainForwardingState(t *testing.T) {
+	cases := map[string]struct {
+		id        string
+		customer  string
+		domain    string
+		subdomain string
+	}{
+		"Given a domain":                   {"example.co.uk", "", "example.co.uk", ""},
+		"Given a subdomain":                {"www.example.co.uk", "", "example.co.uk", "www"},
+		"Given a nested subdomain":         {"shop.eu.example.co.uk", "", "example.co.uk", "shop.eu"},
+		"Given a customer and a subdomain": {"1234:www.example.co.uk", "1234", "example.co.uk", "www"},
+		"Given an unknown domain":          {"www.example.org", "", "", ""},
+	}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path != "/v1/domains/example.co.uk" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"code":"NOT_FOUND","message":"Domain not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"domainId":1,"domain":"example.co.uk","status":"ACTIVE"}`)
+	}))
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := resourceDomainForwarding().Data(nil)
+			d.SetId(tc.id)
+
+			imported, err := importDomainForwardingState(context.Background(), d, client)
+			if tc.domain == "" {
+				assert.NotNil(t, err)
+				return
+			}
+			if !assert.Nil(t, err) || !assert.Len(t, imported, 1) {
+				return
+			}
+
+			assert.Equal(t, api.ForwardingFQDN(tc.domain, tc.subdomain), imported[0].Id())
+			assert.Equal(t, tc.customer, imported[0].Get(zattrCustomer))
+			assert.Equal(t, tc.domain, imported[0].Get(attrDomain))
+			assert.Equal(t, tc.subdomain, imported[0].Get(attrSubdomain))
+		})
+	}
+}
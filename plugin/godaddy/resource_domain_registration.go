@@ -0,0 +1,439 @@
+package godaddy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrPrivacy         = "privacy"
+	attrConsent         = "consent"
+	attrCancelOnDestroy = "cancel_on_destroy"
+	attrOrderID         = "order_id"
+	attrStatus          = "status"
+
+	consentAgreementKeys = "agreement_keys"
+	consentAgreedBy      = "agreed_by"
+	consentAgreedAt      = "agreed_at"
+
+	// defaultRegistrationTimeout bounds the wait for a purchased domain to be registered
+	defaultRegistrationTimeout = 30 * time.Minute
+)
+
+// purchaseAttributes only apply when the domain is purchased
+var purchaseAttributes = []string{attrPeriod, attrPrivacy, attrNameservers, attrConsent, attrRegistrant, attrAdmin, attrTech, attrBilling}
+
+type domainRegistrationResource struct {
+	Customer        string
+	Domain          string
+	Period          int
+	Privacy         bool
+	RenewAuto       bool
+	NameServers     []string
+	AgreementKeys   []string
+	AgreedBy        string
+	AgreedAt        string
+	Contacts        api.DomainContacts
+	CancelOnDestroy bool
+}
+
+func newDomainRegistrationResource(d resourceGetter) *domainRegistrationResource {
+	r := &domainRegistrationResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrPeriod); ok {
+		r.Period = attr.(int)
+	}
+
+	if attr, ok := d.GetOk(attrPrivacy); ok {
+		r.Privacy = attr.(bool)
+	}
+
+	if attr, ok := d.GetOk(attrRenewAuto); ok {
+		r.RenewAuto = attr.(bool)
+	}
+
+	if attr, ok := d.GetOk(attrNameservers); ok {
+		for _, item := range attr.([]interface{}) {
+			r.NameServers = append(r.NameServers, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreementKeys)); ok {
+		for _, item := range attr.([]interface{}) {
+			r.AgreementKeys = append(r.AgreementKeys, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreedBy)); ok {
+		r.AgreedBy = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreedAt)); ok {
+		r.AgreedAt = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrCancelOnDestroy); ok {
+		r.CancelOnDestroy = attr.(bool)
+	}
+
+	r.Contacts = api.DomainContacts{
+		Registrant: expandContact(d, attrRegistrant),
+		Admin:      expandContact(d, attrAdmin),
+		Tech:       expandContact(d, attrTech),
+		Billing:    expandContact(d, attrBilling),
+	}
+
+	return r
+}
+
+func consentPath(attr string) string {
+	return fmt.Sprintf("%s.0.%s", attrConsent, attr)
+}
+
+func consentSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				consentAgreementKeys: {
+					Type:        schema.TypeList,
+					Required:    true,
+					MinItems:    1,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Keys of the accepted agreements, as listed by the GoDaddy agreements API for the TLD.",
+				},
+				consentAgreedBy: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.IsIPAddress,
+					Description:  "IP address of the person who accepted the agreements.",
+				},
+				consentAgreedAt: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsRFC3339Time,
+					Description:  "When the agreements were accepted, formatted as RFC 3339 (defaults to the time of the order).",
+				},
+			},
+		},
+	}
+}
+
+// checkAgreements fails if the consent misses one of the agreements required
+// to register the domain, or to transfer it in
+func checkAgreements(ctx context.Context, client *api.Client, domain string, privacy, forTransfer bool, consent api.Consent) error {
+	agreements, err := client.GetAgreements(ctx, []string{api.TLD(domain)}, privacy, forTransfer)
+	if err != nil {
+		return fmt.Errorf("couldn't fetch agreements (%s): %s", domain, err.Error())
+	}
+
+	if missing := api.MissingAgreements(agreements, consent); len(missing) > 0 {
+		keys := make([]string, len(missing))
+		for i, agreement := range missing {
+			keys[i] = fmt.Sprintf("%s (%s, %s)", agreement.Key, agreement.Title, agreement.URL)
+		}
+		operation := "register"
+		if forTransfer {
+			operation = "transfer"
+		}
+		return fmt.Errorf("consent must accept the agreements required to %s %s: %s", operation, domain, strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
+// purchase builds the order for the domain, consenting to the agreements at
+// the configured time or else at the provided one
+func (r *domainRegistrationResource) purchase(at time.Time) *api.DomainPurchase {
+	consent := api.NewConsent(r.AgreementKeys, r.AgreedBy, at)
+	if r.AgreedAt != "" {
+		consent.AgreedAt = r.AgreedAt
+	}
+
+	return &api.DomainPurchase{
+		Domain:         r.Domain,
+		Consent:        consent,
+		Period:         r.Period,
+		NameServers:    r.NameServers,
+		Privacy:        r.Privacy,
+		RenewAuto:      r.RenewAuto,
+		DomainContacts: r.Contacts,
+	}
+}
+
+func resourceDomainRegistration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainRegistrationCreate,
+		ReadContext:   resourceDomainRegistrationRead,
+		UpdateContext: resourceDomainRegistrationUpdate,
+		DeleteContext: resourceDomainRegistrationDelete,
+		CustomizeDiff: resourceDomainRegistrationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultRegistrationTimeout),
+			Update: schema.DefaultTimeout(defaultPendingTimeout),
+			Delete: schema.DefaultTimeout(defaultPendingTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller registering the domain on behalf of a customer).",
+			},
+			attrPeriod: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 10),
+				Description:  "Number of years to register the domain for. Only applies to the purchase.",
+			},
+			attrPrivacy: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to purchase privacy protection along with the domain. Only applies to the purchase.",
+			},
+			attrRenewAuto: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the domain is renewed automatically before it expires.",
+			},
+			attrNameservers: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Nameservers of the domain (defaults to the GoDaddy nameservers). Only applies to the purchase.",
+			},
+			attrConsent:    consentSchema("Acceptance of the agreements required to register the domain. Only applies to the purchase."),
+			attrRegistrant: contactSchema(true, "Owner of the domain. Only applies to the purchase, see `godaddy_domain_contacts`."),
+			attrAdmin:      contactSchema(false, "Administrative contact (defaults to the registrant). Only applies to the purchase."),
+			attrTech:       contactSchema(false, "Technical contact (defaults to the registrant). Only applies to the purchase."),
+			attrBilling:    contactSchema(false, "Billing contact (defaults to the registrant). Only applies to the purchase."),
+			attrCancelOnDestroy: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether destroying the resource cancels the registration of the domain. " +
+					"By default the domain stays registered and is only removed from state.",
+			},
+			attrOrderID: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the order placed for the purchase.",
+			},
+			attrStatus: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the domain.",
+			},
+		},
+	}
+}
+
+// resourceDomainRegistrationCustomizeDiff validates new purchases at plan
+// time: the contacts, the consent to the agreements of the TLD and the order
+// itself. Purchases with values that are not known yet are validated on apply.
+func resourceDomainRegistrationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || meta == nil || !registrationKnown(d) {
+		return nil
+	}
+
+	client := meta.(*api.Client)
+	r := newDomainRegistrationResource(d)
+	purchase := r.purchase(time.Now())
+
+	if err := purchase.DomainContacts.Validate(); err != nil {
+		return err
+	}
+
+	if err := checkAgreements(ctx, client, r.Domain, r.Privacy, false, purchase.Consent); err != nil {
+		return err
+	}
+
+	if err := client.ValidatePurchase(ctx, r.Customer, purchase); err != nil {
+		return fmt.Errorf("invalid purchase (%s): %s", r.Domain, err.Error())
+	}
+
+	return nil
+}
+
+// registrationKnown reports whether all values of the purchase are known
+func registrationKnown(d *schema.ResourceDiff) bool {
+	for _, attr := range []string{attrDomain, zattrCustomer, attrPeriod, attrPrivacy, attrRenewAuto, attrNameservers,
+		consentPath(consentAgreementKeys), consentPath(consentAgreedBy), consentPath(consentAgreedAt)} {
+		if !d.NewValueKnown(attr) {
+			return false
+		}
+	}
+	for _, role := range contactRoles {
+		if !contactKnown(d, role) {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceDomainRegistrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainRegistrationResource(d)
+
+	log.Println("Fetching", r.Domain, "info...")
+	domain, err := client.GetDomain(ctx, r.Customer, r.Domain)
+	if api.IsNotFound(err) && d.Id() == r.Domain {
+		// the domain was purchased but is not listed yet, see resourceDomainRegistrationCreate
+		log.Println("Domain", r.Domain, "is not registered yet")
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Registration of %s is still pending", r.Domain),
+			Detail:   fmt.Sprintf("The domain was purchased with order %d but is not listed in the account yet.", d.Get(attrOrderID).(int)),
+		}}
+	}
+	if api.IsNotFound(err) || (err == nil && strings.HasPrefix(domain.Status, api.StatusCancelled)) {
+		log.Println("Domain", r.Domain, "is no longer registered, removing it from state")
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if err = d.Set(attrStatus, domain.Status); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set(attrRenewAuto, domain.RenewAuto); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// registrationLookupTimeout bounds the lookup of a purchased domain once the
+// create timeout expired
+const registrationLookupTimeout = 30 * time.Second
+
+func resourceDomainRegistrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainRegistrationResource(d)
+
+	log.Println("Purchasing", r.Domain, "...")
+	order, err := client.PurchaseDomain(ctx, r.Customer, r.purchase(time.Now()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't purchase domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	if err = d.Set(attrOrderID, order.OrderID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Waiting for", r.Domain, "registration...")
+	domain, err := client.WaitForRegistration(ctx, r.Customer, r.Domain, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		// the order is placed, keep it in state even if the registration doesn't complete in time.
+		// The create timeout may have expired the context, the domain is looked up regardless.
+		lookupCtx, cancel := context.WithTimeout(context.Background(), registrationLookupTimeout)
+		defer cancel()
+		if domain, lookupErr := client.GetDomain(lookupCtx, r.Customer, r.Domain); lookupErr == nil {
+			d.SetId(strconv.FormatInt(domain.ID, 10))
+		} else {
+			// the domain has no ID until it is listed, which read picks up
+			d.SetId(r.Domain)
+		}
+
+		if errors.Is(err, api.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Registration of %s is still pending", r.Domain),
+				Detail: fmt.Sprintf("The domain was purchased with order %d and was not registered within the create timeout. "+
+					"Its status is refreshed on the next plan.", order.OrderID),
+			}}
+		}
+		return diag.FromErr(fmt.Errorf("domain (%s) was purchased with order %d but its status is unknown: %s", r.Domain, order.OrderID, err.Error()))
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+	return resourceDomainRegistrationRead(ctx, d, meta)
+}
+
+func resourceDomainRegistrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	var diags diag.Diagnostics
+
+	r := newDomainRegistrationResource(d)
+
+	if d.HasChange(attrRenewAuto) {
+		if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Println("Updating", r.Domain, "settings...")
+		if err := client.UpdateDomain(ctx, r.Customer, r.Domain, &api.DomainUpdate{RenewAuto: &r.RenewAuto}); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if changed := d.HasChanges(purchaseAttributes...); changed {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Purchase settings are not updated",
+			Detail: fmt.Sprintf("The period, privacy, nameservers, consent and contacts of %s only apply to its purchase. "+
+				"Manage the nameservers with godaddy_domain_zone and the contacts with godaddy_domain_contacts.", r.Domain),
+		})
+	}
+
+	return append(diags, resourceDomainRegistrationRead(ctx, d, meta)...)
+}
+
+func resourceDomainRegistrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	var diags diag.Diagnostics
+
+	r := newDomainRegistrationResource(d)
+
+	if !r.CancelOnDestroy {
+		log.Println("Leaving", r.Domain, "registered...")
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Domain left registered",
+			Detail:   fmt.Sprintf("%s was removed from state but remains registered. Set cancel_on_destroy to cancel the registration instead.", r.Domain),
+		})
+	}
+
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Cancelling", r.Domain, "registration...")
+	if err := client.CancelDomain(ctx, r.Customer, r.Domain); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't cancel domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	return diags
+}
@@ -0,0 +1,154 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainRegistrationPurchase(t *testing.T) {
+	registrant := &api.Contact{
+		NameFirst: "Jane",
+		NameLast:  "Doe",
+		Email:     "jane@example.com",
+		Phone:     "+1.4805058800",
+		AddressMailing: api.Address{
+			Address1:   "14455 N Hayden Rd",
+			City:       "Scottsdale",
+			State:      "AZ",
+			PostalCode: "85260",
+			Country:    "US",
+		},
+	}
+	now := time.Date(2023, 11, 6, 11, 49, 42, 0, time.UTC)
+
+	cases := map[string]struct {
+		consent  map[string]interface{}
+		agreedAt string
+	}{
+		"Given a consent without a time": {
+			consent:  map[string]interface{}{consentAgreementKeys: []interface{}{"DNRA"}, consentAgreedBy: "192.0.2.1"},
+			agreedAt: "2023-11-06T11:49:42Z",
+		},
+		"Given a consent with a time": {
+			consent:  map[string]interface{}{consentAgreementKeys: []interface{}{"DNRA"}, consentAgreedBy: "192.0.2.1", consentAgreedAt: "2023-11-01T08:00:00Z"},
+			agreedAt: "2023-11-01T08:00:00Z",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDomainRegistration().Schema, map[string]interface{}{
+				attrDomain:      "example.com",
+				attrConsent:     []interface{}{tc.consent},
+				attrRegistrant:  flattenContact(registrant),
+				attrNameservers: []interface{}{"ns1.example.net"},
+			})
+
+			purchase := newDomainRegistrationResource(d).purchase(now)
+			assert.Equal(t, "example.com", purchase.Domain)
+			assert.Equal(t, 1, purchase.Period)
+			assert.True(t, purchase.RenewAuto)
+			assert.False(t, purchase.Privacy)
+			assert.Equal(t, []string{"ns1.example.net"}, purchase.NameServers)
+			assert.Equal(t, api.Consent{AgreementKeys: []string{"DNRA"}, AgreedBy: "192.0.2.1", AgreedAt: tc.agreedAt}, purchase.Consent)
+			assert.Equal(t, registrant, purchase.Registrant)
+			assert.Nil(t, purchase.Admin)
+		})
+	}
+}
+
+func TestDomainRegistrationCreate(t *testing.T) {
+	var purchases int
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPost {
+			purchases++
+			fmt.Fprint(w, `{"orderId":42,"itemCount":1,"total":11990000,"currency":"USD"}`)
+			return
+		}
+		fmt.Fprint(w, `{"domainId":1234,"domain":"example.com","status":"ACTIVE","renewAuto":true}`)
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceDomainRegistration().Schema, map[string]interface{}{
+		attrDomain:  "example.com",
+		attrConsent: []interface{}{map[string]interface{}{consentAgreementKeys: []interface{}{"DNRA"}, consentAgreedBy: "192.0.2.1"}},
+	})
+	diags := resourceDomainRegistrationCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, 1, purchases)
+
+	// the ID is the domain ID, the same as once the domain is read
+	assert.Equal(t, "1234", d.Id())
+	assert.Equal(t, 42, d.Get(attrOrderID))
+
+	diags = resourceDomainRegistrationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, "1234", d.Id())
+}
+
+func TestDomainRegistrationCreateTimeout(t *testing.T) {
+	cases := map[string]struct {
+		listed bool
+		id     string
+	}{
+		"Given a domain not listed yet":   {false, "example.com"},
+		"Given a domain pending creation": {true, "1234"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case req.Method == http.MethodPost:
+					fmt.Fprint(w, `{"orderId":42,"itemCount":1,"total":11990000,"currency":"USD"}`)
+				case tc.listed:
+					fmt.Fprint(w, `{"domainId":1234,"domain":"example.com","status":"PENDING_DNS_ACTIVE","renewAuto":true}`)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"code":"NOT_FOUND","message":"Domain not found"}`)
+				}
+			}))
+
+			resource := resourceDomainRegistration()
+			resource.Timeouts = &schema.ResourceTimeout{Create: schema.DefaultTimeout(100 * time.Millisecond)}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				attrDomain:  "example.com",
+				attrConsent: []interface{}{map[string]interface{}{consentAgreementKeys: []interface{}{"DNRA"}, consentAgreedBy: "192.0.2.1"}},
+			})
+			diff, err := resource.Diff(context.Background(), nil, config, nil)
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			// the purchase is kept in state rather than tainted
+			state, diags := resource.Apply(context.Background(), nil, diff, client)
+			assert.False(t, diags.HasError(), diags)
+			if assert.Len(t, diags, 1) {
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				assert.Equal(t, "Registration of example.com is still pending", diags[0].Summary)
+			}
+			if !assert.NotNil(t, state) {
+				return
+			}
+			assert.False(t, state.Tainted)
+			assert.Equal(t, tc.id, state.ID)
+
+			// and read keeps it until the registration completes
+			state, diags = resource.RefreshWithoutUpgrade(context.Background(), state, client)
+			assert.False(t, diags.HasError(), diags)
+			if assert.NotNil(t, state) {
+				assert.Equal(t, tc.id, state.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attrRenewalWindow   = "renewal_window_days"
+	attrExpires         = "expires"
+	attrRenewDeadline   = "renew_deadline"
+	attrDaysUntilExpiry = "days_until_expiry"
+	attrRenewedUntil    = "renewed_until"
+	attrLastOrderID     = "last_order_id"
+)
+
+type domainRenewalResource struct {
+	Customer     string
+	Domain       string
+	Period       int
+	Window       int
+	RenewedUntil time.Time
+}
+
+func newDomainRenewalResource(d resourceGetter) (*domainRenewalResource, error) {
+	r := &domainRenewalResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrPeriod); ok {
+		r.Period = attr.(int)
+	}
+
+	if attr, ok := d.GetOk(attrRenewalWindow); ok {
+		r.Window = attr.(int)
+	}
+
+	if attr, ok := d.GetOk(attrRenewedUntil); ok {
+		renewedUntil, err := time.Parse(time.RFC3339, attr.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", attrRenewedUntil, err)
+		}
+		r.RenewedUntil = renewedUntil
+	}
+
+	return r, nil
+}
+
+// expiry is the latest known expiry of the domain. A renewal may take a while
+// to be reflected by the API, the expiry it extended the domain to counts too.
+func (r *domainRenewalResource) expiry(expires time.Time) time.Time {
+	if r.RenewedUntil.After(expires) {
+		return r.RenewedUntil
+	}
+	return expires
+}
+
+// renewalDue reports whether the expiry falls within the renewal window
+func renewalDue(expires time.Time, window int, now time.Time) bool {
+	return !expires.IsZero() && expires.Sub(now) <= time.Duration(window)*24*time.Hour
+}
+
+// checkRenewalWindow rejects a window a renewal can't leave: the domain would
+// be renewed again, and paid for, on every apply
+func checkRenewalWindow(window, period int) error {
+	if window >= period*365 {
+		return fmt.Errorf("%s (%d) must be shorter than the renewal %s (%d years), "+
+			"otherwise the domain is renewed on every apply", attrRenewalWindow, window, attrPeriod, period)
+	}
+	return nil
+}
+
+func resourceDomainRenewal() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainRenewalRenew,
+		ReadContext:   resourceDomainRenewalRead,
+		UpdateContext: resourceDomainRenewalRenew,
+		DeleteContext: resourceDomainRenewalDelete,
+		CustomizeDiff: resourceDomainRenewalCustomizeDiff,
+		Timeouts:      pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrPeriod: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 10),
+				Description:  "Number of years to renew the domain for.",
+			},
+			attrRenewalWindow: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(1, 365),
+				Description:  "The domain is renewed once it expires within this number of days, which must be shorter than the period.",
+			},
+			attrExpires: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the domain expires, formatted as RFC 3339.",
+			},
+			attrRenewDeadline: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last moment the domain can be renewed after it expired, formatted as RFC 3339.",
+			},
+			attrDaysUntilExpiry: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of whole days left before the domain expires, negative once it expired.",
+			},
+			attrRenewedUntil: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry the last renewal extended the domain to, formatted as RFC 3339.",
+			},
+			attrLastOrderID: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the order placed for the last renewal.",
+			},
+		},
+	}
+}
+
+// resourceDomainRenewalCustomizeDiff plans a renewal once the expiry of the
+// domain falls within the renewal window
+func resourceDomainRenewalCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	r, err := newDomainRenewalResource(d)
+	if err != nil {
+		return err
+	}
+
+	if d.NewValueKnown(attrRenewalWindow) && d.NewValueKnown(attrPeriod) {
+		if err = checkRenewalWindow(r.Window, r.Period); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	expires, _ := time.Parse(time.RFC3339, d.Get(attrExpires).(string))
+	if !renewalDue(r.expiry(expires), r.Window, time.Now()) {
+		return nil
+	}
+
+	for _, attr := range []string{attrExpires, attrDaysUntilExpiry, attrRenewedUntil, attrLastOrderID} {
+		if err = d.SetNewComputed(attr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceDomainRenewalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r, err := newDomainRenewalResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Fetching", r.Domain, "info...")
+	domain, err := client.GetDomain(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if err = setExpiry(d, domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceDomainRenewalRenew renews the domain if its expiry falls within the
+// renewal window
+func resourceDomainRenewalRenew(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r, err := newDomainRenewalResource(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if err = checkRenewalWindow(r.Window, r.Period); err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now()
+	expiry := r.expiry(domain.Expires)
+	if renewalDue(expiry, r.Window, now) {
+		if !domain.RenewDeadline.IsZero() && now.After(domain.RenewDeadline) {
+			return diag.Errorf("couldn't renew domain (%s): the renew deadline passed on %s", r.Domain, formatTime(domain.RenewDeadline))
+		}
+		// an expired domain may still be within the window once renewed, don't pay for it twice
+		if renewalDue(expiry.AddDate(r.Period, 0, 0), r.Window, now) {
+			return diag.Errorf("couldn't renew domain (%s): renewing it for %d years would leave its expiry within %s, "+
+				"lower the window or raise the period", r.Domain, r.Period, attrRenewalWindow)
+		}
+
+		log.Println("Renewing", r.Domain, "for", r.Period, "years...")
+		order, err := client.RenewDomain(ctx, r.Customer, r.Domain, r.Period)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't renew domain (%s): %s", r.Domain, err.Error()))
+		}
+
+		if err = d.Set(attrLastOrderID, order.OrderID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set(attrRenewedUntil, formatTime(expiry.AddDate(r.Period, 0, 0))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDomainRenewalRead(ctx, d, meta)
+}
+
+// resourceDomainRenewalDelete stops renewing the domain, which remains registered
+func resourceDomainRenewalDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("No longer renewing", d.Get(attrDomain).(string), "...")
+	d.SetId("")
+	return nil
+}
+
+// setExpiry populates the expiry attributes shared by the renewal resource
+// and the domain data source
+func setExpiry(d *schema.ResourceData, domain *api.Domain) error {
+	for attr, value := range map[string]interface{}{
+		attrExpires:         formatTime(domain.Expires),
+		attrRenewDeadline:   formatTime(domain.RenewDeadline),
+		attrDaysUntilExpiry: domain.DaysUntilExpiry(time.Now()),
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatTime formats the time as RFC 3339, unknown times as an empty string
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
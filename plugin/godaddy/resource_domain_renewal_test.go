@@ -0,0 +1,107 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenewalDue(t *testing.T) {
+	now := time.Date(2023, 11, 6, 12, 0, 0, 0, time.UTC)
+
+	var criteria = []struct {
+		Name         string
+		Expires      time.Time
+		RenewedUntil time.Time
+		Due          bool
+	}{
+		{"Given an unknown expiry", time.Time{}, time.Time{}, false},
+		{"Given an expiry outside of the window", now.AddDate(0, 2, 0), time.Time{}, false},
+		{"Given an expiry within the window", now.AddDate(0, 0, 10), time.Time{}, true},
+		{"Given an expired domain", now.AddDate(0, 0, -3), time.Time{}, true},
+		{"Given a renewal not reflected by the expiry yet", now.AddDate(0, 0, 10), now.AddDate(1, 0, 10), false},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			r := &domainRenewalResource{Window: 30, RenewedUntil: test.RenewedUntil}
+			assert.Equal(t, test.Due, renewalDue(r.expiry(test.Expires), r.Window, now))
+		})
+	}
+}
+
+func TestCheckRenewalWindow(t *testing.T) {
+	assert.Nil(t, checkRenewalWindow(30, 1))
+	assert.Nil(t, checkRenewalWindow(364, 1))
+	assert.NotNil(t, checkRenewalWindow(365, 1))
+	assert.Nil(t, checkRenewalWindow(365, 2))
+}
+
+// newTestClient is a client of the stand-in server without rate limit
+func newTestClient(t *testing.T, handler http.Handler) *api.Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClient(srv.URL, "key", "secret", api.WithRateLimit(60000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestDomainRenewalRenewsOnce(t *testing.T) {
+	cases := map[string]struct {
+		reflected bool
+	}{
+		"Given a renewal reflected by the expiry":     {true},
+		"Given a renewal not reflected by the expiry": {false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var renewals int32
+			expires := time.Now().AddDate(0, 0, 10).UTC().Truncate(time.Second)
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if req.Method == http.MethodPost {
+					atomic.AddInt32(&renewals, 1)
+					if tc.reflected {
+						expires = expires.AddDate(1, 0, 0)
+					}
+					fmt.Fprint(w, `{"orderId":43,"itemCount":1,"total":11990000,"currency":"USD"}`)
+					return
+				}
+				fmt.Fprintf(w, `{"domainId":1,"domain":"example.com","status":"ACTIVE","expires":%q}`, expires.Format(time.RFC3339))
+			}))
+
+			resource := resourceDomainRenewal()
+			config := map[string]interface{}{attrDomain: "example.com", attrRenewalWindow: 30}
+			d := schema.TestResourceDataRaw(t, resource.Schema, config)
+
+			diags := resourceDomainRenewalRenew(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&renewals))
+
+			// the next plan has nothing to renew
+			state, diags := resource.RefreshWithoutUpgrade(context.Background(), d.State(), client)
+			assert.False(t, diags.HasError(), diags)
+			diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+			assert.Nil(t, err)
+			assert.True(t, diff == nil || diff.Empty(), diff)
+
+			// nor does an apply
+			d = resource.Data(state)
+			diags = resourceDomainRenewalRenew(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&renewals))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	attrLocked      = "locked"
+	attrRenewAuto   = "renew_auto"
+	attrExposeWhois = "expose_whois"
+)
+
+type domainSettingsResource struct {
+	Customer string
+	Domain   string
+}
+
+func newDomainSettingsResource(d *schema.ResourceData) *domainSettingsResource {
+	r := &domainSettingsResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	return r
+}
+
+func resourceDomainSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainSettingsUpdate,
+		ReadContext:   resourceDomainSettingsRead,
+		UpdateContext: resourceDomainSettingsUpdate,
+		DeleteContext: resourceDomainSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDomainState,
+		},
+		Timeouts: pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrLocked: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the domain is locked against transfers to another registrar.",
+			},
+			attrRenewAuto: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the domain is renewed automatically before it expires.",
+			},
+			attrExposeWhois: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the contact details are published in WHOIS instead of being kept private.",
+			},
+		},
+	}
+}
+
+func resourceDomainSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainSettingsResource(d)
+
+	log.Println("Fetching", r.Domain, "info...")
+	domain, err := client.GetDomain(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	for attr, value := range map[string]bool{
+		attrLocked:      domain.Locked,
+		attrRenewAuto:   domain.RenewAuto,
+		attrExposeWhois: domain.ExposeWhois,
+	} {
+		if err = d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDomainSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainSettingsResource(d)
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, timeout)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if update, changed := settingsUpdate(d); changed {
+		log.Println("Updating", r.Domain, "settings...")
+		if err = client.UpdateDomain(ctx, r.Customer, r.Domain, update); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDomainSettingsRead(ctx, d, meta)
+}
+
+// resourceDomainSettingsDelete stops managing the settings, which remain as
+// they are on the domain
+func resourceDomainSettingsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Println("Leaving", d.Get(attrDomain).(string), "settings as they are...")
+	d.SetId("")
+	return nil
+}
+
+// settingsUpdate collects the configured settings: all of them when the
+// resource is created and the changed ones afterwards. Settings missing from
+// the configuration are left as they are.
+func settingsUpdate(d *schema.ResourceData) (*api.DomainUpdate, bool) {
+	update := &api.DomainUpdate{}
+	changed := false
+	config := d.GetRawConfig()
+
+	for attr, setting := range map[string]**bool{
+		attrLocked:      &update.Locked,
+		attrRenewAuto:   &update.RenewAuto,
+		attrExposeWhois: &update.ExposeWhois,
+	} {
+		if config.IsNull() || config.GetAttr(attr).IsNull() {
+			continue
+		}
+		if !d.IsNewResource() && !d.HasChange(attr) {
+			continue
+		}
+
+		value := d.Get(attr).(bool)
+		*setting = &value
+		changed = true
+	}
+
+	return update, changed
+}
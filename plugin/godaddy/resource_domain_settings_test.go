@@ -0,0 +1,97 @@
+package godaddy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// settingsServer serves the settings of a domain and records the updates
+type settingsServer struct {
+	sync.Mutex
+	settings map[string]bool
+	updates  []string
+}
+
+func (s *settingsServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch req.Method {
+	case http.MethodPatch:
+		body, _ := io.ReadAll(req.Body)
+		s.updates = append(s.updates, string(body))
+		json.Unmarshal(body, &s.settings)
+	case http.MethodGet:
+		fmt.Fprintf(w, `{"domainId":1,"domain":"example.com","status":"ACTIVE","locked":%t,"renewAuto":%t,"exposeWhois":%t}`,
+			s.settings["locked"], s.settings["renewAuto"], s.settings["exposeWhois"])
+	}
+}
+
+// settingsConfig is the configuration of the resource, along with the raw
+// configuration Terraform sends telling the settings left out apart
+func settingsConfig(settings map[string]bool) (*terraform.ResourceConfig, cty.Value) {
+	config := map[string]interface{}{attrDomain: "example.com"}
+	raw := map[string]cty.Value{attrDomain: cty.StringVal("example.com"), zattrCustomer: cty.NullVal(cty.String)}
+
+	for _, attr := range []string{attrLocked, attrRenewAuto, attrExposeWhois} {
+		if value, ok := settings[attr]; ok {
+			config[attr] = value
+			raw[attr] = cty.BoolVal(value)
+		} else {
+			raw[attr] = cty.NullVal(cty.Bool)
+		}
+	}
+
+	return terraform.NewResourceConfigRaw(config), cty.ObjectVal(raw)
+}
+
+func TestDomainSettingsUpdate(t *testing.T) {
+	ctx := context.Background()
+	server := &settingsServer{settings: map[string]bool{"locked": true, "renewAuto": true, "exposeWhois": true}}
+	client := newTestClient(t, server)
+	resource := resourceDomainSettings()
+
+	// only the configured settings are written on create
+	config, raw := settingsConfig(map[string]bool{attrRenewAuto: false})
+	diff, err := resource.Diff(ctx, nil, config, client)
+	if !assert.Nil(t, err) {
+		return
+	}
+	diff.RawConfig = raw
+
+	state, diags := resource.Apply(ctx, nil, diff, client)
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, server.updates, 1) {
+		assert.JSONEq(t, `{"renewAuto":false}`, server.updates[0])
+	}
+	assert.Equal(t, "1", state.ID)
+	assert.Equal(t, "true", state.Attributes[attrLocked])
+	assert.Equal(t, "false", state.Attributes[attrRenewAuto])
+	assert.Equal(t, "true", state.Attributes[attrExposeWhois])
+
+	// only the changed setting is written on update
+	config, raw = settingsConfig(map[string]bool{attrRenewAuto: false, attrExposeWhois: false})
+	diff, err = resource.Diff(ctx, state, config, client)
+	if !assert.Nil(t, err) {
+		return
+	}
+	diff.RawConfig = raw
+
+	state, diags = resource.Apply(ctx, state, diff, client)
+	assert.False(t, diags.HasError(), diags)
+	if assert.Len(t, server.updates, 2) {
+		assert.JSONEq(t, `{"exposeWhois":false}`, server.updates[1])
+	}
+	assert.Equal(t, "true", state.Attributes[attrLocked])
+	assert.Equal(t, "false", state.Attributes[attrExposeWhois])
+}
@@ -0,0 +1,299 @@
+package godaddy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// defaultTransferTimeout bounds the wait for a transfer, the losing
+	// registrar has five days to acknowledge it
+	defaultTransferTimeout = 5 * 24 * time.Hour
+)
+
+// transferAttributes only apply when the transfer is ordered
+var transferAttributes = []string{attrAuthCode, attrPeriod, attrPrivacy, attrRenewAuto, attrConsent}
+
+type domainTransferInResource struct {
+	Customer      string
+	Domain        string
+	AuthCode      string
+	Period        int
+	Privacy       bool
+	RenewAuto     bool
+	AgreementKeys []string
+	AgreedBy      string
+	AgreedAt      string
+}
+
+func newDomainTransferInResource(d resourceGetter) *domainTransferInResource {
+	r := &domainTransferInResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrAuthCode); ok {
+		r.AuthCode = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrPeriod); ok {
+		r.Period = attr.(int)
+	}
+
+	if attr, ok := d.GetOk(attrPrivacy); ok {
+		r.Privacy = attr.(bool)
+	}
+
+	if attr, ok := d.GetOk(attrRenewAuto); ok {
+		r.RenewAuto = attr.(bool)
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreementKeys)); ok {
+		for _, item := range attr.([]interface{}) {
+			r.AgreementKeys = append(r.AgreementKeys, item.(string))
+		}
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreedBy)); ok {
+		r.AgreedBy = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(consentPath(consentAgreedAt)); ok {
+		r.AgreedAt = attr.(string)
+	}
+
+	return r
+}
+
+// transfer builds the order for the domain, consenting to the agreements at
+// the configured time or else at the provided one
+func (r *domainTransferInResource) transfer(at time.Time) *api.DomainTransfer {
+	consent := api.NewConsent(r.AgreementKeys, r.AgreedBy, at)
+	if r.AgreedAt != "" {
+		consent.AgreedAt = r.AgreedAt
+	}
+
+	return &api.DomainTransfer{
+		AuthCode:  r.AuthCode,
+		Consent:   consent,
+		Period:    r.Period,
+		Privacy:   r.Privacy,
+		RenewAuto: r.RenewAuto,
+	}
+}
+
+func resourceDomainTransferIn() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainTransferInCreate,
+		ReadContext:   resourceDomainTransferInRead,
+		UpdateContext: resourceDomainTransferInUpdate,
+		DeleteContext: resourceDomainTransferInDelete,
+		CustomizeDiff: resourceDomainTransferInCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTransferTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller transferring the domain on behalf of a customer).",
+			},
+			attrAuthCode: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Code issued by the losing registrar to authorize the transfer. Only applies to the transfer.",
+			},
+			attrPeriod: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, 10),
+				Description:  "Number of years the transfer extends the registration by. Only applies to the transfer.",
+			},
+			attrPrivacy: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether to purchase privacy protection along with the transfer. Only applies to the transfer.",
+			},
+			attrRenewAuto: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the domain is renewed automatically once transferred. Only applies to the transfer, see `godaddy_domain_settings`.",
+			},
+			attrConsent: consentSchema("Acceptance of the agreements required to transfer the domain. Only applies to the transfer."),
+			attrOrderID: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the order placed for the transfer.",
+			},
+			attrStatus: {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Status of the transfer: `ACCEPTED`, `PENDING` or `AWAITING` while it is processed, " +
+					"then `SUCCESS`, `FAILED` or `CANCELLED`.",
+			},
+		},
+	}
+}
+
+// resourceDomainTransferInCustomizeDiff checks at plan time that new transfers
+// accept the agreements of the TLD
+func resourceDomainTransferInCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || meta == nil {
+		return nil
+	}
+
+	for _, attr := range []string{attrDomain, attrPrivacy, consentPath(consentAgreementKeys), consentPath(consentAgreedBy), consentPath(consentAgreedAt)} {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+	}
+
+	r := newDomainTransferInResource(d)
+	return checkAgreements(ctx, meta.(*api.Client), r.Domain, r.Privacy, true, r.transfer(time.Now()).Consent)
+}
+
+func resourceDomainTransferInRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainTransferInResource(d)
+
+	log.Println("Fetching", r.Domain, "transfer status...")
+	action, err := client.GetDomainAction(ctx, r.Customer, r.Domain, api.ActionTransfer)
+	if api.IsNotFound(err) {
+		// GoDaddy may not record the transfer yet, or no longer
+		return nil
+	} else if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find transfer (%s): %s", r.Domain, err.Error()))
+	}
+
+	if action.Status == api.ActionFailed || action.Status == api.ActionCancelled {
+		log.Println("Transfer of", r.Domain, "ended with status", action.Status+", removing it from state")
+		d.SetId("")
+		return transferFailedDiagnostics(diag.Warning, &api.ActionError{Domain: r.Domain, Action: action})
+	}
+
+	if err = d.Set(attrStatus, action.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDomainTransferInCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainTransferInResource(d)
+
+	// the transfer is tracked with the v2 API, make sure it can be before ordering
+	if _, err := client.GetCustomerID(ctx, r.Customer); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't track transfer (%s): %s", r.Domain, err.Error()))
+	}
+
+	log.Println("Transferring", r.Domain, "...")
+	// a transfer left over from an earlier order doesn't tell how this one goes
+	after, err := client.LastActionTime(ctx, r.Customer, r.Domain, api.ActionTransfer)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't transfer domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	order, err := client.TransferDomain(ctx, r.Customer, r.Domain, r.transfer(time.Now()))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't transfer domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	// the order is placed, keep it in state even if the transfer doesn't complete in time
+	d.SetId(r.Domain)
+	if err = d.Set(attrOrderID, order.OrderID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set(attrStatus, api.ActionAccepted); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("Waiting for", r.Domain, "transfer...")
+	action, err := client.WaitForTransfer(ctx, r.Customer, r.Domain, after, d.Timeout(schema.TimeoutCreate))
+
+	var actionErr *api.ActionError
+	switch {
+	case errors.As(err, &actionErr):
+		return transferFailedDiagnostics(diag.Error, actionErr)
+	case errors.Is(err, api.ErrTimeout):
+		if action != nil {
+			if err := d.Set(attrStatus, action.Status); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Transfer of %s is still in progress", r.Domain),
+			Detail: fmt.Sprintf("The transfer was ordered with order %d and did not complete within the create timeout. "+
+				"Its status is refreshed on the next plan.", order.OrderID),
+		}}
+	case err != nil:
+		return diag.FromErr(fmt.Errorf("domain (%s) was ordered for transfer with order %d but its status is unknown: %s", r.Domain, order.OrderID, err.Error()))
+	}
+
+	return resourceDomainTransferInRead(ctx, d, meta)
+}
+
+func resourceDomainTransferInUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.HasChanges(transferAttributes...) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Transfer settings are not updated",
+			Detail: fmt.Sprintf("The auth code, period, privacy, auto-renewal and consent of %s only apply to its transfer. "+
+				"Manage the settings of the transferred domain with godaddy_domain_settings.", d.Get(attrDomain).(string)),
+		})
+	}
+
+	return append(diags, resourceDomainTransferInRead(ctx, d, meta)...)
+}
+
+// resourceDomainTransferInDelete stops tracking the transfer, which can't be
+// undone. A transferred domain stays in the account.
+func resourceDomainTransferInDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	domain := d.Get(attrDomain).(string)
+
+	log.Println("Leaving", domain, "transfer as it is...")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Transfer left as it is",
+		Detail:   fmt.Sprintf("%s was removed from state. A transfer in progress continues and a transferred domain stays in the account.", domain),
+	}}
+}
+
+// transferFailedDiagnostics explains a transfer that failed, most of the time
+// because the losing registrar rejected it
+func transferFailedDiagnostics(severity diag.Severity, err *api.ActionError) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("Transfer of %s was not completed", err.Domain),
+		Detail: err.Error() + ". The losing registrar may have rejected the transfer: check there that the domain is unlocked, " +
+			"that the auth code is current and that the domain was not registered or transferred within the last 60 days. " +
+			"Applying again orders a new transfer.",
+	}}
+}
@@ -0,0 +1,30 @@
+package godaddy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainTransferInTransfer(t *testing.T) {
+	now := time.Date(2023, 11, 6, 11, 49, 42, 0, time.UTC)
+
+	d := schema.TestResourceDataRaw(t, resourceDomainTransferIn().Schema, map[string]interface{}{
+		attrDomain:   "example.com",
+		attrAuthCode: "Xy7#kQ2p",
+		attrPrivacy:  true,
+		attrConsent:  []interface{}{map[string]interface{}{consentAgreementKeys: []interface{}{"DNTA"}, consentAgreedBy: "192.0.2.1"}},
+	})
+
+	transfer := newDomainTransferInResource(d).transfer(now)
+	assert.Equal(t, &api.DomainTransfer{
+		AuthCode:  "Xy7#kQ2p",
+		Consent:   api.Consent{AgreementKeys: []string{"DNTA"}, AgreedBy: "192.0.2.1", AgreedAt: "2023-11-06T11:49:42Z"},
+		Period:    1,
+		Privacy:   true,
+		RenewAuto: true,
+	}, transfer)
+}
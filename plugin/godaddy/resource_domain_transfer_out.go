@@ -0,0 +1,159 @@
+package godaddy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	attrAuthCode = "auth_code"
+)
+
+type domainTransferOutResource struct {
+	Customer string
+	Domain   string
+}
+
+func newDomainTransferOutResource(d resourceGetter) *domainTransferOutResource {
+	r := &domainTransferOutResource{}
+
+	if attr, ok := d.GetOk(zattrCustomer); ok {
+		r.Customer = attr.(string)
+	}
+
+	if attr, ok := d.GetOk(attrDomain); ok {
+		r.Domain = attr.(string)
+	}
+
+	return r
+}
+
+func resourceDomainTransferOut() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDomainTransferOutCreate,
+		ReadContext:   resourceDomainTransferOutRead,
+		DeleteContext: resourceDomainTransferOutDelete,
+		Timeouts:      pendingTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			attrDomain: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			zattrCustomer: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Customer ID (required if you are a reseller managing a domain purchased outside the scope of your reseller account).",
+			},
+			attrAuthCode: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Code the registrar the domain is transferred to needs to authorize the transfer.",
+			},
+			attrLocked: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the domain is locked against transfers, which is the case again once the resource is destroyed.",
+			},
+		},
+	}
+}
+
+// resourceDomainTransferOutCreate unlocks the domain, the auth code to hand
+// over to the gaining registrar is read afterwards
+func resourceDomainTransferOutCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainTransferOutResource(d)
+
+	domain, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	if domain.Locked {
+		log.Println("Unlocking", r.Domain, "...")
+		if err = client.LockDomain(ctx, r.Customer, r.Domain, false); err != nil {
+			return diag.FromErr(fmt.Errorf("couldn't unlock domain (%s): %s", r.Domain, err.Error()))
+		}
+	}
+
+	return resourceDomainTransferOutRead(ctx, d, meta)
+}
+
+// resourceDomainTransferOutRead fetches the auth code anew while the domain
+// is unlocked, GoDaddy may have generated another one since it was stored
+func resourceDomainTransferOutRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainTransferOutResource(d)
+
+	log.Println("Fetching", r.Domain, "info...")
+	domain, err := client.GetDomain(ctx, r.Customer, r.Domain)
+	if api.IsNotFound(err) {
+		// the transfer completed, the domain is no longer managed by GoDaddy
+		log.Println(r.Domain, "was transferred out")
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't find domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	if err = d.Set(attrLocked, domain.Locked); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if domain.Locked {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s was locked again", r.Domain),
+			Detail:   "Transfers to another registrar are rejected while the domain is locked. Replace the resource to unlock it.",
+		}}
+	}
+
+	log.Println("Fetching", r.Domain, "auth code...")
+	authCode, err := client.GetAuthCode(ctx, r.Customer, r.Domain)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't fetch auth code (%s): %s", r.Domain, err.Error()))
+	}
+
+	if err = d.Set(attrAuthCode, authCode); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceDomainTransferOutDelete locks the domain again, unless it was
+// transferred out in the meantime
+func resourceDomainTransferOutDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*api.Client)
+	r := newDomainTransferOutResource(d)
+
+	if _, err := waitForDomain(ctx, client, r.Customer, r.Domain, d.Timeout(schema.TimeoutDelete)); err != nil {
+		if api.IsNotFound(err) {
+			log.Println(r.Domain, "was transferred out")
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	log.Println("Locking", r.Domain, "...")
+	if err := client.LockDomain(ctx, r.Customer, r.Domain, true); err != nil {
+		return diag.FromErr(fmt.Errorf("couldn't lock domain (%s): %s", r.Domain, err.Error()))
+	}
+
+	d.SetId("")
+	return nil
+}
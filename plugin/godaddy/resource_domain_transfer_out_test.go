@@ -0,0 +1,130 @@
+package godaddy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/b13f/terraform-provider-godaddy/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// transferOutServer serves a domain that can be locked and unlocked, or that
+// was transferred out if gone is set
+type transferOutServer struct {
+	sync.Mutex
+	locked   bool
+	gone     bool
+	authCode string
+	updates  []bool
+}
+
+func (s *transferOutServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if s.gone {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"NOT_FOUND","message":"Domain not found"}`)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPatch:
+		var update api.DomainUpdate
+		json.NewDecoder(req.Body).Decode(&update)
+		s.locked = *update.Locked
+		s.updates = append(s.updates, s.locked)
+	case http.MethodGet:
+		fmt.Fprintf(w, `{"domainId":1,"domain":"example.com","status":"ACTIVE","locked":%t,"authCode":%q}`, s.locked, s.authCode)
+	}
+}
+
+func TestDomainTransferOutCreate(t *testing.T) {
+	server := &transferOutServer{locked: true, authCode: "Xy7#kQ2p"}
+	client := newTestClient(t, server)
+
+	d := schema.TestResourceDataRaw(t, resourceDomainTransferOut().Schema, map[string]interface{}{attrDomain: "example.com"})
+	diags := resourceDomainTransferOutCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), diags)
+
+	assert.Equal(t, []bool{false}, server.updates)
+	assert.Equal(t, "1", d.Id())
+	assert.Equal(t, false, d.Get(attrLocked))
+	assert.Equal(t, "Xy7#kQ2p", d.Get(attrAuthCode))
+}
+
+func TestDomainTransferOutRead(t *testing.T) {
+	var criteria = []struct {
+		Name     string
+		Locked   bool
+		Gone     bool
+		Stored   string
+		AuthCode string
+		Warning  bool
+	}{
+		{"Given an auth code missing from state", false, false, "", "Xy7#kQ2p", false},
+		{"Given a stale auth code", false, false, "Ab1$cD2e", "Xy7#kQ2p", false},
+		{"Given a domain locked again", true, false, "Ab1$cD2e", "Ab1$cD2e", true},
+		{"Given a domain transferred out", false, true, "Ab1$cD2e", "", false},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			server := &transferOutServer{locked: test.Locked, gone: test.Gone, authCode: "Xy7#kQ2p"}
+			client := newTestClient(t, server)
+
+			d := schema.TestResourceDataRaw(t, resourceDomainTransferOut().Schema, map[string]interface{}{attrDomain: "example.com"})
+			d.SetId("1")
+			assert.Nil(t, d.Set(attrAuthCode, test.Stored))
+
+			diags := resourceDomainTransferOutRead(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			if test.Warning {
+				if assert.Len(t, diags, 1) {
+					assert.Equal(t, diag.Warning, diags[0].Severity)
+					assert.Equal(t, "example.com was locked again", diags[0].Summary)
+				}
+			} else {
+				assert.Empty(t, diags)
+			}
+
+			if test.Gone {
+				assert.Empty(t, d.Id())
+				return
+			}
+			assert.Equal(t, test.Locked, d.Get(attrLocked))
+			assert.Equal(t, test.AuthCode, d.Get(attrAuthCode))
+		})
+	}
+}
+
+func TestDomainTransferOutDelete(t *testing.T) {
+	var criteria = []struct {
+		Name    string
+		Gone    bool
+		Updates []bool
+	}{
+		{"Given a domain still registered", false, []bool{true}},
+		{"Given a domain transferred out", true, nil},
+	}
+	for _, test := range criteria {
+		t.Run(test.Name, func(t *testing.T) {
+			server := &transferOutServer{gone: test.Gone}
+			client := newTestClient(t, server)
+
+			d := schema.TestResourceDataRaw(t, resourceDomainTransferOut().Schema, map[string]interface{}{attrDomain: "example.com"})
+			d.SetId("1")
+
+			diags := resourceDomainTransferOutDelete(context.Background(), d, client)
+			assert.False(t, diags.HasError(), diags)
+			assert.Empty(t, d.Id())
+			assert.Equal(t, test.Updates, server.updates)
+		})
+	}
+}